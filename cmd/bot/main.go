@@ -1,7 +1,7 @@
 package main
 
 import (
-	"log"
+	"log/slog"
 	"space_arena/internal/bot"
 	"space_arena/internal/utils"
 	"strconv"
@@ -9,23 +9,25 @@ import (
 )
 
 func main() {
+	utils.SetupLogger()
 	numberOfBots, err := strconv.Atoi(utils.Getevn("NUMBER_OF_BOTS", "8"))
 	if err != nil {
-		log.Println(err.Error())
+		slog.Error("invalid NUMBER_OF_BOTS", "err", err)
 		return
 	}
 	serverAddr := utils.Getevn("SERVER_ADDR", "")
+	queue := utils.Getevn("BOT_QUEUE", "")
 
 	bots := make(chan *bot.Bot, numberOfBots)
 	for range numberOfBots {
-		bots <- bot.CreateBot()
+		bots <- bot.CreateBot(queue)
 	}
 
 	for b := range bots {
 		go func() {
 			b.Run(serverAddr)
 			time.Sleep(time.Second)
-			bots <- bot.CreateBot()
+			bots <- bot.CreateBot(queue)
 		}()
 	}
 }
@@ -2,8 +2,10 @@ package main
 
 import (
 	"space_arena/internal/server"
+	"space_arena/internal/utils"
 )
 
 func main() {
+	utils.SetupLogger()
 	server.New().Run()
 }
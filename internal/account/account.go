@@ -0,0 +1,51 @@
+package account
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+const (
+	ACCOUNT_NAME_MIN_LEN     = 3
+	ACCOUNT_NAME_MAX_LEN     = 16
+	ACCOUNT_PASSWORD_MIN_LEN = 8
+	ACCOUNT_PASSWORD_MAX_LEN = 72
+)
+
+var (
+	ErrAccountExists   = errors.New("account already exists")
+	ErrAccountNotFound = errors.New("account not found")
+	ErrInvalidName     = errors.New("invalid account name")
+	ErrInvalidPassword = errors.New("invalid password")
+)
+
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+type Account struct {
+	Id           string    `json:"id"`
+	Name         string    `json:"name"`
+	PasswordHash string    `json:"password_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// 계정 저장소
+type Store interface {
+	Create(a *Account) error
+	GetById(id string) (*Account, error)
+	GetByName(name string) (*Account, error)
+}
+
+func ValidateName(name string) error {
+	if len(name) < ACCOUNT_NAME_MIN_LEN || len(name) > ACCOUNT_NAME_MAX_LEN || !accountNamePattern.MatchString(name) {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func ValidatePassword(password string) error {
+	if len(password) < ACCOUNT_PASSWORD_MIN_LEN || len(password) > ACCOUNT_PASSWORD_MAX_LEN {
+		return ErrInvalidPassword
+	}
+	return nil
+}
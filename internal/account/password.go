@@ -0,0 +1,61 @@
+package account
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	PASSWORD_HASH_ALGORITHM  = "pbkdf2-sha256"
+	PASSWORD_HASH_ITERATIONS = 100000
+	PASSWORD_SALT_LEN        = 16
+	PASSWORD_KEY_LEN         = 32
+)
+
+// "알고리즘$반복 횟수$salt$hash" 형식으로 인코딩된 비밀번호 해시 생성
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, PASSWORD_SALT_LEN)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("HashPassword: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, PASSWORD_HASH_ITERATIONS, PASSWORD_KEY_LEN)
+	if err != nil {
+		return "", fmt.Errorf("HashPassword: %w", err)
+	}
+	return strings.Join([]string{
+		PASSWORD_HASH_ALGORITHM,
+		strconv.Itoa(PASSWORD_HASH_ITERATIONS),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+func VerifyPassword(password, encoded string) bool {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 4 || parts[0] != PASSWORD_HASH_ALGORITHM {
+		return false
+	}
+	iter, err := strconv.Atoi(parts[1])
+	if err != nil || iter <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(want) == 0 {
+		return false
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, iter, len(want))
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare(key, want) == 1
+}
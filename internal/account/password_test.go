@@ -0,0 +1,47 @@
+package account
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(hash, "$")
+
+	tests := []struct {
+		name     string
+		password string
+		hash     string
+		want     bool
+	}{
+		{name: "correct password", password: "correct horse", hash: hash, want: true},
+		{name: "wrong password", password: "correct horsE", hash: hash, want: false},
+		{name: "empty password", password: "", hash: hash, want: false},
+		{name: "empty hash", password: "correct horse", hash: "", want: false},
+		{name: "missing field", password: "correct horse", hash: strings.Join(parts[:3], "$"), want: false},
+		{name: "unknown algorithm", password: "correct horse", hash: "md5$" + strings.Join(parts[1:], "$"), want: false},
+		{name: "invalid iterations", password: "correct horse", hash: strings.Join([]string{parts[0], "x", parts[2], parts[3]}, "$"), want: false},
+		{name: "zero iterations", password: "correct horse", hash: strings.Join([]string{parts[0], "0", parts[2], parts[3]}, "$"), want: false},
+		{name: "salt not base64", password: "correct horse", hash: strings.Join([]string{parts[0], parts[1], "!!!", parts[3]}, "$"), want: false},
+		{name: "empty key", password: "correct horse", hash: strings.Join([]string{parts[0], parts[1], parts[2], ""}, "$"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyPassword(tt.password, tt.hash); got != tt.want {
+				t.Errorf("VerifyPassword = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPasswordSalted(t *testing.T) {
+	a, _ := HashPassword("password1")
+	b, _ := HashPassword("password1")
+	if a == b {
+		t.Error("same password hashed to same value, salt not applied")
+	}
+}
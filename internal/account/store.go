@@ -0,0 +1,120 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"space_arena/internal/utils"
+	"strings"
+	"sync"
+)
+
+// 메모리 기반 계정 저장소
+type MemoryStore struct {
+	mu     sync.RWMutex
+	byId   map[string]*Account
+	byName map[string]*Account // 소문자로 변환한 이름 기준
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		byId:   make(map[string]*Account),
+		byName: make(map[string]*Account),
+	}
+}
+
+func (ms *MemoryStore) Create(a *Account) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return ms.create(a)
+}
+
+func (ms *MemoryStore) create(a *Account) error {
+	name := strings.ToLower(a.Name)
+	if _, ok := ms.byName[name]; ok {
+		return ErrAccountExists
+	}
+	if _, ok := ms.byId[a.Id]; ok {
+		return ErrAccountExists
+	}
+	ms.byId[a.Id] = a
+	ms.byName[name] = a
+	return nil
+}
+
+func (ms *MemoryStore) GetById(id string) (*Account, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	a, ok := ms.byId[id]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	return a, nil
+}
+
+func (ms *MemoryStore) GetByName(name string) (*Account, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	a, ok := ms.byName[strings.ToLower(name)]
+	if !ok {
+		return nil, ErrAccountNotFound
+	}
+	return a, nil
+}
+
+// JSON 파일 기반 계정 저장소: 계정이 추가될 때마다 파일 전체를 다시 기록
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	fs := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fs, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewFileStore: %w", err)
+	}
+
+	accounts := []*Account{}
+	if err := json.Unmarshal(data, &accounts); err != nil {
+		return nil, fmt.Errorf("NewFileStore: %s: %w", path, err)
+	}
+	for _, a := range accounts {
+		if err := fs.create(a); err != nil {
+			return nil, fmt.Errorf("NewFileStore: %s: %s: %w", path, a.Name, err)
+		}
+	}
+	return fs, nil
+}
+
+func (fs *FileStore) Create(a *Account) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := fs.create(a); err != nil {
+		return err
+	}
+	if err := fs.save(); err != nil {
+		// 저장에 실패한 경우 메모리에서도 삭제
+		delete(fs.byId, a.Id)
+		delete(fs.byName, strings.ToLower(a.Name))
+		return err
+	}
+	return nil
+}
+
+func (fs *FileStore) save() error {
+	accounts := make([]*Account, 0, len(fs.byId))
+	for _, a := range fs.byId {
+		accounts = append(accounts, a)
+	}
+	data, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return fmt.Errorf("FileStore.save: %w", err)
+	}
+	return utils.WriteFileAtomic(fs.path, data)
+}
@@ -0,0 +1,77 @@
+package account
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func testStores(t *testing.T) map[string]func() Store {
+	return map[string]func() Store{
+		"memory": func() Store { return NewMemoryStore() },
+		"file": func() Store {
+			fs, err := NewFileStore(filepath.Join(t.TempDir(), "accounts.json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			return fs
+		},
+	}
+}
+
+func TestStoreDuplicate(t *testing.T) {
+	tests := []struct {
+		name    string
+		account *Account
+		wantErr error
+	}{
+		{name: "new account", account: &Account{Id: "ID2", Name: "bob"}},
+		{name: "same name", account: &Account{Id: "ID3", Name: "alice"}, wantErr: ErrAccountExists},
+		{name: "same name different case", account: &Account{Id: "ID4", Name: "ALICE"}, wantErr: ErrAccountExists},
+		{name: "same id", account: &Account{Id: "ID1", Name: "carol"}, wantErr: ErrAccountExists},
+	}
+	for storeName, newStore := range testStores(t) {
+		for _, tt := range tests {
+			t.Run(storeName+"/"+tt.name, func(t *testing.T) {
+				store := newStore()
+				if err := store.Create(&Account{Id: "ID1", Name: "alice"}); err != nil {
+					t.Fatal(err)
+				}
+				if err := store.Create(tt.account); !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Create err = %v, want %v", err, tt.wantErr)
+				}
+				// 실패한 경우 기존 계정은 유지
+				a, err := store.GetByName("Alice")
+				if err != nil || a.Id != "ID1" {
+					t.Errorf("GetByName(Alice) = %v, %v", a, err)
+				}
+			})
+		}
+	}
+}
+
+func TestFileStoreReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	fs, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.Create(&Account{Id: "ID1", Name: "alice", PasswordHash: "hash"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reloaded, err := NewFileStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := reloaded.GetById("ID1")
+	if err != nil || a.Name != "alice" || a.PasswordHash != "hash" {
+		t.Fatalf("GetById after reload = %v, %v", a, err)
+	}
+	if err := reloaded.Create(&Account{Id: "ID2", Name: "Alice"}); !errors.Is(err, ErrAccountExists) {
+		t.Errorf("duplicate after reload err = %v, want %v", err, ErrAccountExists)
+	}
+	if _, err := reloaded.GetByName("nobody"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("GetByName(nobody) err = %v, want %v", err, ErrAccountNotFound)
+	}
+}
@@ -0,0 +1,67 @@
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("expired token")
+)
+
+// HMAC-SHA256으로 서명된 세션 토큰 발급 및 검증
+// 토큰 형식: base64url("계정 아이디|만료 시각(unix)").base64url(서명)
+type TokenSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenSigner(secret []byte, ttl time.Duration) *TokenSigner {
+	return &TokenSigner{secret: secret, ttl: ttl}
+}
+
+func (ts *TokenSigner) Sign(accountId string) string {
+	expires := time.Now().Add(ts.ttl).Unix()
+	payload := base64.RawURLEncoding.EncodeToString([]byte(accountId + "|" + strconv.FormatInt(expires, 10)))
+	return payload + "." + base64.RawURLEncoding.EncodeToString(ts.mac(payload))
+}
+
+// 토큰을 검증하고 계정 아이디를 반환
+func (ts *TokenSigner) Verify(token string) (string, error) {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, ts.mac(payload)) {
+		return "", ErrInvalidToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	accountId, expiresStr, ok := strings.Cut(string(data), "|")
+	if !ok || accountId == "" {
+		return "", ErrInvalidToken
+	}
+	expires, err := strconv.ParseInt(expiresStr, 10, 64)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	if time.Now().Unix() > expires {
+		return "", ErrExpiredToken
+	}
+	return accountId, nil
+}
+
+func (ts *TokenSigner) mac(payload string) []byte {
+	h := hmac.New(sha256.New, ts.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,46 @@
+package account
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenSigner(t *testing.T) {
+	signer := NewTokenSigner([]byte("secret"), time.Hour)
+	token := signer.Sign("ACCOUNT01")
+	payload, sig, _ := strings.Cut(token, ".")
+
+	tampered := base64.RawURLEncoding.EncodeToString([]byte("ACCOUNT02|9999999999")) + "." + sig
+	otherSigner := NewTokenSigner([]byte("other secret"), time.Hour)
+	expired := NewTokenSigner([]byte("secret"), -time.Hour).Sign("ACCOUNT01")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantId  string
+		wantErr error
+	}{
+		{name: "round trip", token: token, wantId: "ACCOUNT01"},
+		{name: "tampered payload", token: tampered, wantErr: ErrInvalidToken},
+		{name: "bad signature", token: payload + "." + base64.RawURLEncoding.EncodeToString([]byte("bad")), wantErr: ErrInvalidToken},
+		{name: "signed with other secret", token: otherSigner.Sign("ACCOUNT01"), wantErr: ErrInvalidToken},
+		{name: "signature not base64", token: payload + ".!!!", wantErr: ErrInvalidToken},
+		{name: "missing signature", token: payload, wantErr: ErrInvalidToken},
+		{name: "empty", token: "", wantErr: ErrInvalidToken},
+		{name: "expired", token: expired, wantErr: ErrExpiredToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := signer.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if id != tt.wantId {
+				t.Errorf("id = %q, want %q", id, tt.wantId)
+			}
+		})
+	}
+}
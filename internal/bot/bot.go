@@ -2,10 +2,11 @@ package bot
 
 import (
 	"encoding/json"
-	"log"
+	"log/slog"
 	"math/rand"
 	"net/url"
 	"space_arena/internal/model"
+	"space_arena/internal/utils"
 	"sync"
 	"time"
 
@@ -14,50 +15,56 @@ import (
 
 type Bot struct {
 	id        string
+	queue     string // 참가할 매칭 대기열, 비어 있으면 기본 대기열
 	isDead    bool
 	dirX      int
 	dirY      int
 	dirR      int
 	conn      *websocket.Conn
 	sendMutex sync.Mutex
+	logger    *slog.Logger // 클라이언트 아이디와 게임 아이디를 포함한 로거
 }
 
-func CreateBot() *Bot {
-	return &Bot{}
+func CreateBot(queue string) *Bot {
+	return &Bot{queue: queue, logger: slog.Default()}
 }
 
 func (b *Bot) Run(serverAddr string) {
 	var err error
 	u := url.URL{Scheme: "ws", Host: serverAddr, Path: "/ws"}
-	log.Printf("connecting to %s", u.String())
+	b.logger.Info("connecting", "url", u.String())
 
 	b.conn, _, err = websocket.DefaultDialer.Dial(u.String(), nil)
 	if err != nil {
-		log.Fatal("dial:", err)
+		utils.Fatal("dial error", "err", err)
 	}
 	defer b.conn.Close()
 
 	for {
 		_, message, err := b.conn.ReadMessage()
 		if err != nil {
-			log.Println("read:", err)
+			b.logger.Warn("read error", "err", err)
 			break
 		}
 		var msg model.Msg
 		if err := json.Unmarshal(message, &msg); err != nil {
-			log.Println("json unmarshal error", err)
+			b.logger.Warn("json unmarshal error", "err", err)
 			break
 		}
 
 		switch msg.Type {
 		case model.MSG_TYPE_HELLO:
 			b.id = msg.ClientId
+			b.logger = slog.With("client_id", b.id)
 			b.sendMsg(model.Msg{
 				ClientId: b.id,
 				Type:     model.MSG_TYPE_READY,
+				Queue:    b.queue,
 			})
 
 		case model.MSG_TYPE_START:
+			b.logger = slog.With("client_id", b.id, "game_id", msg.Event.Data.Id)
+			b.logger.Info("game start")
 			time.Sleep(time.Millisecond * 1500)
 			go b.run()
 
@@ -68,7 +75,7 @@ func (b *Bot) Run(serverAddr string) {
 				b.isDead = true
 			}
 		}
-		log.Printf("recv: %s %s %s", msg.ClientId, msg.Type, msg.Event.Type)
+		b.logger.Debug("recv", "msg_type", msg.Type, "event_type", msg.Event.Type)
 	}
 }
 
@@ -157,5 +164,5 @@ func (b *Bot) sendMsg(msg model.Msg) {
 	defer b.sendMutex.Unlock()
 	data, _ := json.Marshal(msg)
 	b.conn.WriteMessage(websocket.TextMessage, data)
-	log.Printf("send: %s %s", msg.ClientId, msg.Event.Type)
+	b.logger.Debug("send", "msg_type", msg.Type, "event_type", msg.Event.Type)
 }
@@ -2,100 +2,207 @@ package game
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"math"
-	"math/rand"
+	"space_arena/internal/metrics"
 	"space_arena/internal/model"
 	"space_arena/internal/utils"
+	"sync/atomic"
 	"time"
 )
 
+const (
+	GAME_MODE_FFA  = "ffa"  // 개인전
+	GAME_MODE_TEAM = "team" // 팀전
+)
+
+const (
+	GAME_TICK_RATE          = 30 // 초당 틱 수, 한 스텝의 시뮬레이션 시간은 1/GAME_TICK_RATE초로 고정
+	GAME_MAX_STEPS_PER_TICK = 4  // 틱이 밀린 경우 한 틱에서 따라잡을 최대 스텝 수
+)
+
+// 모든 게임의 틱 처리 통계
+var (
+	// 틱 처리 시간(sec)
+	TickDuration = metrics.NewHistogram([]float64{0.001, 0.002, 0.005, 0.01, 0.02, 0.033, 0.05, 0.1, 0.25})
+	// 처리 시간이 틱 간격을 넘은 틱 수
+	TickOverruns = &metrics.Counter{}
+	// 따라잡지 못해 버린 스텝 수
+	SkippedSteps = &metrics.Counter{}
+)
+
 type Game struct {
-	id                string                              // 게임 아이디
-	worldSize         float64                             // 월드 범위
-	worldMinSize      float64                             // 월드 범위 최소 크기
-	worldSpeed        float64                             // 월드 범위가 좁혀지는 속도(per sec)
-	worldFireCooldown float64                             // 발사체 생성 쿨다운 시간(sec)
-	players           *utils.SafeMap[string, *Player]     // 모든 플레이어 목록
-	playersAlive      *utils.SafeMap[string, *Player]     // 생존한 플레이어 목록
-	projectiles       *utils.SafeMap[string, *Projectile] // 모든 발사체 목록
-	eventRecvChan     chan model.Event                    // 이벤트 수신 채널
-	eventSendChan     chan model.Event                    // 이벤트 전송 채널
+	id            string                              // 게임 아이디
+	mode          string                              // 게임 모드
+	mapName       string                              // 맵 이름
+	worldSize     float64                             // 월드 범위
+	worldMinSize  float64                             // 현재 축소 단계의 목표 크기
+	worldSpeed    float64                             // 월드 범위가 좁혀지는 속도(per sec)
+	zone          []MapZonePhase                      // 월드 범위 축소 일정
+	zonePhase     int                                 // 현재 축소 단계
+	zoneWait      float64                             // 현재 축소 단계 시작까지 남은 시간(sec)
+	hazards       []*HazardEmitter                    // 발사체 생성기 목록
+	latencyWait   float64                             // 다음 지연 시간 전송까지 남은 시간(sec)
+	rammingDamage bool                                // 우주선 충돌 피해 여부
+	players       *utils.SafeMap[string, *Player]     // 모든 플레이어 목록
+	playersAlive  *utils.SafeMap[string, *Player]     // 생존한 플레이어 목록
+	participants  map[string]*Player                  // 게임에 참가한 모든 플레이어 목록(연결이 끊겨도 삭제하지 않음)
+	projectiles   *utils.SafeMap[string, *Projectile] // 모든 발사체 목록
+	obstacles     *utils.SafeMap[string, *Obstacle]   // 모든 장애물 목록
+	eventRecvChan chan model.Event                    // 이벤트 수신 채널
+	eventSendChan chan model.Event                    // 이벤트 전송 채널
+	startedAt     time.Time                           // 게임 시작 시각
+	endedAt       time.Time                           // 게임 종료 시각
+	elapsed       float64                             // 게임 진행 시간(sec)
+	tick          atomic.Int64                        // 처리한 틱 수
+	tickLag       atomic.Int64                        // 마지막 틱이 예정보다 늦게 시작된 시간(ns)
+	onViolation   func(playerId, reason string)       // 플레이어의 잘못된 입력 처리 콜백
+	stopped       atomic.Bool                         // 관리자에 의한 강제 종료 여부
 }
 
-func NewGame(id string, clients []*model.Client) *Game {
+// teams는 clients와 같은 순서의 팀 번호이며, nil이면 개인전
+func NewGame(id string, clients []*model.Client, m *Map, teams []int) *Game {
 	g := Game{}
 	g.id = id
-	g.worldSize = GAME_OBJECT_WIDTH * 9
-	g.worldMinSize = GAME_OBJECT_WIDTH * 2
-	g.worldSpeed = GAME_OBJECT_WIDTH * 0.05
-	g.worldFireCooldown = 5
+	g.mode = GAME_MODE_FFA
+	if teams != nil {
+		g.mode = GAME_MODE_TEAM
+	}
+	g.mapName = m.Name
+	g.startedAt = time.Now()
+	g.rammingDamage = m.RammingDamage
+	g.worldSize = m.Boundary.Size * GAME_OBJECT_WIDTH
+	g.worldMinSize = g.worldSize
+	g.zone = m.Zone
+	g.zonePhase = -1
+	g.nextZonePhase()
 
 	g.players = utils.NewSafeMap[string, *Player]()
 	g.playersAlive = utils.NewSafeMap[string, *Player]()
+	g.participants = map[string]*Player{}
 	g.projectiles = utils.NewSafeMap[string, *Projectile]()
+	g.obstacles = utils.NewSafeMap[string, *Obstacle]()
 
 	g.eventRecvChan = make(chan model.Event, 1000)
 	g.eventSendChan = make(chan model.Event, 1000)
 
 	// 플레이어 생성
 	for i, c := range clients {
-		// 랜덤한 월드 영역 경계에서 스폰되도록 위치 계산
-		angle := 2 * math.Pi * float64(i) / float64(len(clients))
-		x := g.worldSize * math.Cos(angle)
-		y := g.worldSize * math.Sin(angle)
-
-		player := CreatePlayer(c.Id, i, c, x, y, angle-math.Pi/2)
+		x, y, angle := m.spawn(i, len(clients))
+		player := CreatePlayer(c.Id, i, c, x, y, angle)
+		player.FlightModel = m.FlightModel
+		if teams != nil {
+			player.Team = teams[i]
+		}
+		player.Name = c.Name
+		if c.Skin != model.CLIENT_SKIN_DEFAULT {
+			player.Skin = c.Skin
+		}
 		g.players.Set(c.Id, player)
 		g.playersAlive.Set(c.Id, player)
+		g.participants[c.Id] = player
+	}
+
+	// 장애물 생성
+	for _, o := range m.createObstacles() {
+		g.obstacles.Set(o.Id, o)
+	}
+
+	// 발사체 생성기 생성
+	for _, h := range m.Hazards {
+		g.hazards = append(g.hazards, CreateHazardEmitter(h))
 	}
 
 	return &g
 }
 
+// 플레이어가 잘못된 이벤트를 보낸 경우 호출할 콜백 설정: 게임 루프 고루틴에서 호출됨
+func (g *Game) SetViolationHandler(fn func(playerId, reason string)) {
+	g.onViolation = fn
+}
+
+func (g *Game) violation(p *Player, reason string) {
+	g.logger().Debug("player violation", "client_id", p.Id, "reason", reason)
+	if g.onViolation != nil {
+		g.onViolation(p.Id, reason)
+	}
+}
+
+func (g *Game) Id() string      { return g.id }
+func (g *Game) Mode() string    { return g.mode }
+func (g *Game) MapName() string { return g.mapName }
+
+// 게임 강제 종료: 다음 틱에 결과 없이 게임 루프를 종료
+func (g *Game) Stop() {
+	g.stopped.Store(true)
+}
+
+// 강제 종료된 게임인지 여부
+func (g *Game) Stopped() bool {
+	return g.stopped.Load()
+}
+
+// 게임 아이디와 현재 틱을 포함한 로거: 게임 루프 고루틴에서 사용
+func (g *Game) logger() *slog.Logger {
+	return slog.With("game_id", g.id, "tick", g.tick.Load())
+}
+
 func (g *Game) Run() {
-	tickRate := 30
-	interval := time.Second / time.Duration(tickRate)
+	interval := time.Second / GAME_TICK_RATE
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	// 게임 루프 시작
+	// 게임 루프 시작: 경과 시간을 고정 간격(interval)의 스텝으로 나누어 시뮬레이션
 	endGame := false
 	lastTime := time.Now()
+	var pending time.Duration // 아직 시뮬레이션하지 않은 경과 시간
 	for range ticker.C {
 		now := time.Now()
-		dt := now.Sub(lastTime).Seconds()
+		elapsed := now.Sub(lastTime)
+		g.tickLag.Store(int64(max(elapsed-interval, 0)))
 		lastTime = now
+		g.tick.Add(1)
+
+		// 강제 종료
+		if g.stopped.Load() {
+			g.logger().Info("game stopped", "elapsed", g.elapsed)
+			g.endedAt = now
+			g.players.Range(func(id string, p *Player) bool {
+				p.Client.AddMsg(model.MakeErrorMsg(id, "game terminated"))
+				return true
+			})
+			break
+		}
 
 		// 이벤트 처리
 		g.eventHandler()
 
-		// 게임 업데이트
-		g.update(dt)
-
-		// 게임 종료 체크
-		if g.playersAlive.Len() <= 1 {
-			g.playersAlive.Range(func(id string, player *Player) bool {
-				// 이동 및 회전 중지
-				g.eventSendChan <- model.Event{
-					Type: model.EVENT_TYPE_PLAYER_MOVE, OwnerId: player.Id,
-					Data: model.EventData{
-						X: player.X, Y: player.Y, Angle: player.Angle,
-						DirX: 0, DirY: 0, DirR: 0,
-					},
-				}
-
-				//승리 메시지 전송
-				player.Client.AddMsg(model.MakeMsg(player.Id, model.MSG_TYPE_INGAME, model.Event{
-					Type: model.EVENT_TYPE_GAME_VICTORY, OwnerId: player.Id,
-				}))
-				return true
-			})
-			endGame = true
+		// 밀린 시간만큼 스텝을 진행하되, 최대 스텝 수를 넘는 시간은 버림
+		pending += elapsed
+		steps := 0
+		for pending >= interval && steps < GAME_MAX_STEPS_PER_TICK && !endGame {
+			g.update(interval.Seconds())
+			pending -= interval
+			steps++
+			endGame = g.checkGameOver()
+
+			// 이벤트 전송
+			g.broadcastEvent()
+		}
+		if pending >= interval && !endGame {
+			skipped := pending / interval
+			SkippedSteps.Add(int64(skipped))
+			g.logger().Warn("game simulation falling behind", "skipped_steps", int64(skipped))
+			pending %= interval
 		}
 
-		// 이벤트 전송
-		g.broadcastEvent()
+		// 틱 처리 시간이 틱 간격을 넘으면 기록
+		duration := time.Since(now)
+		TickDuration.Observe(duration.Seconds())
+		if duration > interval {
+			TickOverruns.Inc()
+			g.logger().Warn("game tick overrun", "duration", duration, "steps", steps)
+		}
 
 		// 게임 종료
 		if endGame {
@@ -111,6 +218,30 @@ func (g *Game) Run() {
 	close(g.eventSendChan)
 }
 
+// 게임 종료 체크: 생존한 팀이 하나 이하이면 승리 처리 후 true 반환
+func (g *Game) checkGameOver() bool {
+	if len(g.aliveTeams()) > 1 {
+		return false
+	}
+	g.endedAt = time.Now()
+	g.playersAlive.Range(func(id string, player *Player) bool {
+		player.SurvivalTime = g.elapsed
+
+		// 이동 및 회전 중지
+		g.eventSendChan <- model.Event{
+			Type: model.EVENT_TYPE_PLAYER_MOVE, OwnerId: player.Id,
+			Data: model.EventData{
+				X: player.X, Y: player.Y, Angle: player.Angle,
+				DirX: 0, DirY: 0, DirR: 0,
+			},
+		}
+		return true
+	})
+	g.victory()
+	g.logger().Info("game over", "elapsed", g.elapsed)
+	return true
+}
+
 func (g *Game) createProjectile(ownerId string, typ int, x, y, angle float64) {
 	projectile := CreateProjectile(ownerId, typ, x, y, angle)
 	g.projectiles.Set(projectile.Id, projectile)
@@ -129,31 +260,232 @@ func (g *Game) createProjectile(ownerId string, typ int, x, y, angle float64) {
 	g.eventSendChan <- ev
 }
 
+// 다음 축소 단계로 진행하고, 축소 대기 상태로 전환
+func (g *Game) nextZonePhase() {
+	g.worldSpeed = 0
+	if g.zonePhase+1 >= len(g.zone) {
+		return
+	}
+	g.zonePhase++
+	phase := g.zone[g.zonePhase]
+	g.zoneWait = phase.Wait
+	g.worldMinSize = phase.Size * GAME_OBJECT_WIDTH
+}
+
+func (g *Game) updateZone(dt float64) {
+	if g.zonePhase < 0 || g.zonePhase >= len(g.zone) {
+		return
+	}
+
+	// 축소 대기
+	if g.worldSpeed == 0 {
+		if g.worldSize <= g.worldMinSize {
+			return
+		}
+		g.zoneWait -= dt
+		if g.zoneWait > 0 {
+			return
+		}
+		// 축소 시작
+		g.worldSpeed = g.zone[g.zonePhase].Speed * GAME_OBJECT_WIDTH
+		g.eventSendChan <- g.makeZoneEvent(model.EVENT_TYPE_GAME_ZONE)
+	}
+
+	g.worldSize -= g.worldSpeed * dt
+	if g.worldSize <= g.worldMinSize {
+		// 현재 단계 축소 완료
+		g.worldSize = g.worldMinSize
+		g.nextZonePhase()
+		g.eventSendChan <- g.makeZoneEvent(model.EVENT_TYPE_GAME_ZONE)
+	}
+}
+
+// 월드 범위와 축소 일정 이벤트: Idx는 현재 축소 단계
+func (g *Game) makeZoneEvent(typ string) model.Event {
+	wait := 0.0
+	if g.worldSpeed == 0 && g.worldSize > g.worldMinSize {
+		wait = math.Max(g.zoneWait, 0)
+	}
+	return model.Event{
+		Type:    typ,
+		OwnerId: g.id,
+		Data: model.EventData{
+			Idx:       g.zonePhase,
+			X:         g.worldSize,
+			Y:         g.worldMinSize,
+			MoveSpeed: g.worldSpeed,
+			Wait:      wait,
+		},
+	}
+}
+
+func (g *Game) makePlayerMoveEvent(p *Player) model.Event {
+	return model.Event{
+		Type: model.EVENT_TYPE_PLAYER_MOVE, OwnerId: p.Id,
+		Data: model.EventData{
+			Idx: p.Idx, X: p.X, Y: p.Y, Angle: p.Angle,
+			DirX: p.DirX, DirY: p.DirY, DirR: p.DirR,
+			VX: p.VX, VY: p.VY, VR: p.VR,
+		},
+	}
+}
+
+// 겹친 우주선을 서로 밀어내고 넉백을 적용, 충돌 피해가 있는 경우 피격된 플레이어를 playersHit에 추가
+func (g *Game) collidePlayers(playersHit map[string]*playerHit) {
+	players := g.playersAlive.Values()
+	for i := 0; i < len(players); i++ {
+		for j := i + 1; j < len(players); j++ {
+			a, b := players[i], players[j]
+			if a.IsDead || b.IsDead ||
+				!utils.CircleCollision(a.X, a.Y, PLAYER_COLLISION_RADIUS, b.X, b.Y, PLAYER_COLLISION_RADIUS) {
+				continue
+			}
+
+			// 충돌 방향(a -> b)
+			nx, ny := b.X-a.X, b.Y-a.Y
+			dist := math.Hypot(nx, ny)
+			if dist == 0 {
+				nx, ny, dist = math.Cos(a.Angle), math.Sin(a.Angle), 1
+			}
+			nx, ny = nx/dist, ny/dist
+
+			// 겹친 만큼 서로 밀어냄
+			overlap := PLAYER_COLLISION_RADIUS*2 - dist
+			a.X -= nx * overlap / 2
+			a.Y -= ny * overlap / 2
+			b.X += nx * overlap / 2
+			b.Y += ny * overlap / 2
+
+			// 접근 속도에 따른 넉백 적용
+			avx, avy := a.Velocity()
+			bvx, bvy := b.Velocity()
+			aSpeed := avx*nx + avy*ny    // a가 b 방향으로 접근하는 속도
+			bSpeed := -(bvx*nx + bvy*ny) // b가 a 방향으로 접근하는 속도
+			closing := aSpeed + bSpeed
+			impulse := math.Max(closing, PLAYER_KNOCKBACK_MIN)
+			a.AddImpulse(-nx*impulse, -ny*impulse)
+			b.AddImpulse(nx*impulse, ny*impulse)
+
+			// 충돌 피해: 더 느리게 접근한 우주선이 피격, 같은 팀끼리는 넉백만 적용
+			if g.rammingDamage && a.Team != b.Team && closing >= PLAYER_RAM_SPEED && aSpeed != bSpeed {
+				victim, rammer := a, b
+				if aSpeed > bSpeed {
+					victim, rammer = b, a
+				}
+				if !victim.IsInvulnerable() {
+					playersHit[victim.Id] = &playerHit{player: victim, killerId: rammer.Id, weapon: GAME_WEAPON_RAM}
+				}
+			}
+
+			// 충돌 이벤트 전송
+			a.SyncCooldown = PLAYER_SYNC_COOLDOWN
+			b.SyncCooldown = PLAYER_SYNC_COOLDOWN
+			g.eventSendChan <- model.Event{
+				Type:    model.EVENT_TYPE_PLAYER_COLLIDE,
+				OwnerId: a.Id,
+				Data: model.EventData{
+					Id: b.Id,
+					X:  (a.X + b.X) / 2, Y: (a.Y + b.Y) / 2,
+					MoveSpeed: closing,
+				},
+			}
+			g.eventSendChan <- g.makePlayerMoveEvent(a)
+			g.eventSendChan <- g.makePlayerMoveEvent(b)
+		}
+	}
+}
+
+func (g *Game) makeObstacleEvent(typ string, o *Obstacle) model.Event {
+	return model.Event{
+		Type:    typ,
+		OwnerId: g.id,
+		Data: model.EventData{
+			Id:  o.Id,
+			Idx: o.Type,
+			X:   o.X, Y: o.Y, Angle: o.Angle,
+			MoveSpeed: o.MoveSpeed,
+			R:         o.R,
+			Hp:        o.Hp,
+		},
+	}
+}
+
+// 이번 틱에 피격된 플레이어와 피격시킨 오브젝트의 소유자
+type playerHit struct {
+	player   *Player
+	killerId string
+	weapon   int
+}
+
 func (g *Game) update(dt float64) {
+	g.elapsed += dt
+
 	// 발사체 생성
-	g.worldFireCooldown -= dt
-	if g.worldFireCooldown <= 0 && g.projectiles.Len() < 50 {
-		for range rand.Intn(10) + 5 {
+	for _, h := range g.hazards {
+		for range h.Update(dt, g.projectiles.Len()) {
 			angle := utils.RandRange(0, math.Pi*2)
-			g.createProjectile(g.id, GAME_PROJECTILE_TYPE_ENERGYBALL, 0, 0, angle-math.Pi/2)
+			g.createProjectile(g.id, h.ProjectileType, h.X, h.Y, angle-math.Pi/2)
 		}
-		g.worldFireCooldown = utils.RandRange(0.25, 1.5)
 	}
 
 	// 월드 업데이트
-	g.worldSize -= g.worldSpeed * dt
-	if g.worldSize < g.worldMinSize {
-		g.worldSize = g.worldMinSize
+	g.updateZone(dt)
+
+	// 플레이어별 지연 시간 전송
+	g.latencyWait -= dt
+	if g.latencyWait <= 0 {
+		g.latencyWait = PLAYER_LATENCY_INTERVAL
+		g.sendLatency()
 	}
 
+	// 장애물 업데이트
+	g.obstacles.Range(func(id string, o *Obstacle) bool {
+		o.Update(dt)
+
+		// 월드 영역 경계에서 반사된 경우 이동 이벤트 전송
+		if o.Bounce(g.worldSize) {
+			g.eventSendChan <- g.makeObstacleEvent(model.EVENT_TYPE_OBSTACLE_MOVE, o)
+		}
+		return true
+	})
+	obstacles := g.obstacles.Values()
+	playersHit := map[string]*playerHit{}
+	playersPrev := map[string][2]float64{} // 이번 스텝에서 이동하기 전 플레이어 위치
+
 	// 플레이어 업데이트
 	g.playersAlive.Range(func(id string, p *Player) bool {
 		// 플레이어 생존 체크
 		if p.IsDead {
 			return true
 		}
+		playersPrev[id] = [2]float64{p.X, p.Y}
+
+		// 플레이어 대시 체크
+		boost, boostReady := p.CheckBoost(dt)
+		if boost {
+			p.SyncCooldown = 0
+			g.eventSendChan <- model.Event{
+				Type: model.EVENT_TYPE_PLAYER_BOOST, OwnerId: p.Id,
+				Data: model.EventData{
+					Idx: p.Idx, X: p.X, Y: p.Y, Angle: p.Angle,
+					VX: p.VX, VY: p.VY,
+					Cooldown: p.BoostCooldown,
+				},
+			}
+		} else if boostReady {
+			// 대시 사용 가능 메시지 전송
+			p.Client.AddMsg(model.MakeMsg(p.Id, model.MSG_TYPE_INGAME, model.Event{
+				Type: model.EVENT_TYPE_PLAYER_BOOST_READY, OwnerId: p.Id,
+			}))
+		}
 
-		p.Update(dt)
+		// 장애물에 막히거나 관성으로 이동하는 경우 클라이언트 예측 위치와 달라지므로 위치 동기화
+		p.SyncCooldown -= dt
+		blocked := p.Update(dt, obstacles)
+		if (blocked || p.HasInertia()) && p.SyncCooldown <= 0 {
+			p.SyncCooldown = PLAYER_SYNC_COOLDOWN
+			g.eventSendChan <- g.makePlayerMoveEvent(p)
+		}
 
 		// 월드 영역 밖으로 나가지 않도록 체크
 		dist := math.Hypot(p.X, p.Y)
@@ -165,40 +497,100 @@ func (g *Game) update(dt float64) {
 
 		// 플레이어 발사 체크
 		if p.CheckFire(dt) {
+			p.ShotsFired++
 			// 발사체 오브젝트 생성
 			g.createProjectile(p.Id, GAME_PROJECTILE_TYPE_LASER, p.X, p.Y, p.Angle-math.Pi/2)
 		}
 		return true
 	})
 
+	// 플레이어 간 충돌 처리
+	g.collidePlayers(playersHit)
+
 	// 발사체 업데이트
 	projectilesDelete := map[string]*Projectile{}
-	playersHit := map[string]*Player{}
+	obstaclesHit := map[string]*Obstacle{}
 	g.projectiles.Range(func(id string, prj *Projectile) bool {
+		x0, y0 := prj.X, prj.Y
 		prj.Update(dt)
 
 		if prj.LiftTime <= 0 {
 			projectilesDelete[prj.Id] = prj
 		}
 
-		// 플레이어와의 충돌 체크
+		// 이번 스텝의 이동 경로에서 가장 먼저 닿는 장애물 검색: 빠른 발사체가 한 스텝에 장애물을 통과하지 않도록 경로 전체를 체크
+		var hitObstacle *Obstacle
+		obstacleT := math.Inf(1)
+		for _, o := range obstacles {
+			t, ok := utils.SweptCircleCollision(x0, y0, prj.X, prj.Y, o.X, o.Y, prj.W/2+o.R)
+			if !ok || t >= obstacleT {
+				continue
+			}
+			// 수정에 반사된 직후 표면에서 멀어지는 발사체는 제외
+			if o.Type == GAME_OBSTACLE_TYPE_CRYSTAL && t == 0 && (prj.X-x0)*(x0-o.X)+(prj.Y-y0)*(y0-o.Y) >= 0 {
+				continue
+			}
+			hitObstacle, obstacleT = o, t
+		}
+
+		// 이번 스텝의 이동 경로에서 가장 먼저 닿는 플레이어 검색
+		var hitPlayer *Player
+		playerT := math.Inf(1)
 		g.playersAlive.Range(func(id string, player *Player) bool {
-			// 자기 자신이 발사한 발사체와는 충돌 체크하지 않음
-			if prj.OwnerId == player.Id {
+			// 자기 자신 또는 같은 팀이 발사한 발사체와는 충돌 체크하지 않음
+			if prj.OwnerId == player.Id || g.isTeammate(prj.OwnerId, player) {
 				return true
 			}
-			// 이미 충돌된 플레이어인지 체크
-			if _, ok := playersHit[player.Id]; ok {
+			// 이미 충돌된 플레이어 또는 무적 상태인 플레이어인지 체크
+			if _, ok := playersHit[player.Id]; ok || player.IsInvulnerable() {
 				return true
 			}
-			// 충돌 체크
-			if utils.CircleCollision(prj.X, prj.Y, prj.W/2, player.X, player.Y, player.W/4) {
-				projectilesDelete[prj.Id] = prj
-				playersHit[player.Id] = player
-				return false
+			// 플레이어도 이동하므로 플레이어 기준의 상대 경로로 체크
+			sx, sy := x0, y0
+			if prev, ok := playersPrev[id]; ok {
+				sx += player.X - prev[0]
+				sy += player.Y - prev[1]
+			}
+			if t, ok := utils.SweptCircleCollision(sx, sy, prj.X, prj.Y, player.X, player.Y, prj.W/2+PLAYER_COLLISION_RADIUS); ok && t < playerT {
+				hitPlayer, playerT = player, t
 			}
 			return true
 		})
+
+		switch {
+		case hitObstacle != nil && obstacleT <= playerT:
+			o := hitObstacle
+			if o.Type == GAME_OBSTACLE_TYPE_CRYSTAL {
+				// 충돌 지점에서 반사된 발사체 이벤트 전송
+				prj.X = x0 + (prj.X-x0)*obstacleT
+				prj.Y = y0 + (prj.Y-y0)*obstacleT
+				o.Deflect(prj)
+				g.eventSendChan <- model.Event{
+					Type:    model.EVENT_TYPE_PROJECTILE_DEFLECT,
+					OwnerId: prj.OwnerId,
+					Data: model.EventData{
+						Id:  prj.Id,
+						Idx: prj.Type,
+						X:   prj.X, Y: prj.Y, Angle: prj.Angle,
+						MoveSpeed: prj.MoveSpeed,
+					},
+				}
+				break
+			}
+			// 장애물에 흡수된 발사체 삭제
+			projectilesDelete[prj.Id] = prj
+			if o.IsDestructible() {
+				o.Hp--
+				obstaclesHit[o.Id] = o
+			}
+
+		case hitPlayer != nil:
+			projectilesDelete[prj.Id] = prj
+			playersHit[hitPlayer.Id] = &playerHit{player: hitPlayer, killerId: prj.OwnerId, weapon: prj.Type}
+			if owner, ok := g.participants[prj.OwnerId]; ok {
+				owner.ShotsHit++
+			}
+		}
 		return true
 	})
 
@@ -216,20 +608,73 @@ func (g *Game) update(dt float64) {
 		g.eventSendChan <- ev
 	}
 
+	// 피격된 장애물 처리
+	for id, o := range obstaclesHit {
+		if o.Hp > 0 {
+			g.eventSendChan <- g.makeObstacleEvent(model.EVENT_TYPE_OBSTACLE_HIT, o)
+			continue
+		}
+		// 파괴된 소행성 분열
+		g.obstacles.Delete(id)
+		g.eventSendChan <- g.makeObstacleEvent(model.EVENT_TYPE_OBSTACLE_DESTROY, o)
+		for _, child := range o.Split() {
+			g.obstacles.Set(child.Id, child)
+			g.eventSendChan <- g.makeObstacleEvent(model.EVENT_TYPE_OBSTACLE_CREATE, child)
+		}
+	}
+
 	// 플레이어 게임오버 처리
-	for id, player := range playersHit {
-		player.IsDead = true
+	for id := range playersHit {
 		g.playersAlive.Delete(id)
-		// 플레이어 죽음 이벤트 전파
-		g.eventSendChan <- model.Event{
-			Type:    model.EVENT_TYPE_PLAYER_DEAD,
-			OwnerId: player.Id,
-			Data: model.EventData{
-				X: player.X,
-				Y: player.Y,
-			},
-		}
 	}
+	for _, hit := range playersHit {
+		g.killPlayer(hit.player, hit.killerId, hit.weapon)
+	}
+}
+
+// 플레이어 죽음 기록 및 이벤트 전파
+// 팀의 마지막 생존자가 죽으면 팀 전체의 순위가 정해지며, 같은 틱에 탈락한 팀은 같은 순위
+func (g *Game) killPlayer(p *Player, killerId string, weapon int) {
+	p.IsDead = true
+	p.KillerId = killerId
+	p.SurvivalTime = g.elapsed
+	alive := g.aliveTeams()
+	if alive[p.Team] == 0 {
+		g.setTeamPlacement(p.Team, len(alive)+1)
+	}
+
+	// 다른 플레이어에게 탈락된 경우 킬 수 증가
+	killerName := ""
+	killer, ok := g.participants[killerId]
+	if ok && killer != p {
+		killer.Kills++
+		killerName = killer.Name
+	}
+
+	// 플레이어 죽음 이벤트 전파
+	g.eventSendChan <- model.Event{
+		Type:    model.EVENT_TYPE_PLAYER_DEAD,
+		OwnerId: p.Id,
+		Data: model.EventData{
+			X: p.X, Y: p.Y,
+			KillerId: killerId, Weapon: weapon,
+		},
+	}
+
+	// 킬 피드 이벤트 전파
+	ev := model.Event{
+		Type:    model.EVENT_TYPE_KILL_FEED,
+		OwnerId: killerId,
+		Data: model.EventData{
+			Id: p.Id, Name: p.Name,
+			KillerId: killerId, KillerName: killerName,
+			Weapon: weapon,
+		},
+	}
+	if ok {
+		ev.Data.Kills = killer.Kills
+	}
+	g.eventSendChan <- ev
 }
 
 func (g *Game) AddEvent(ev model.Event) error {
@@ -244,36 +689,70 @@ func (g *Game) AddEvent(ev model.Event) error {
 func (g *Game) DeletePlayer(id string) {
 	g.players.Delete(id)
 	g.playersAlive.Delete(id)
-	g.AddEvent(model.Event{Type: model.EVENT_TYPE_PLAYER_DISCONNECT, OwnerId: id})
+	// 이벤트를 처리하지 못하고 게임이 끝나면 Result에서 마지막 순위로 기록
+	if err := g.AddEvent(model.Event{Type: model.EVENT_TYPE_PLAYER_DISCONNECT, OwnerId: id}); err != nil {
+		g.logger().Warn("player disconnect event dropped", "client_id", id, "err", err)
+	}
+}
+
+func (g *Game) Player(id string) (*Player, bool) {
+	return g.players.Get(id)
+}
+
+// 연결된 모든 플레이어 목록
+func (g *Game) Players() []*Player {
+	return g.players.Values()
+}
+
+func (g *Game) IsAlive(id string) bool {
+	_, ok := g.playersAlive.Get(id)
+	return ok
+}
+
+// 각 플레이어에게 웹소켓 ping/pong으로 측정한 자신의 지연 시간 전송
+func (g *Game) sendLatency() {
+	g.players.Range(func(id string, p *Player) bool {
+		rtt := p.Client.RTT()
+		if rtt <= 0 {
+			return true
+		}
+		p.Client.AddMsg(model.MakeMsg(id, model.MSG_TYPE_INGAME, model.Event{
+			Type: model.EVENT_TYPE_PLAYER_LATENCY, OwnerId: id,
+			Data: model.EventData{Idx: p.Idx, Latency: float64(rtt) / float64(time.Millisecond)},
+		}))
+		return true
+	})
 }
 
 func (g *Game) sendInitData(id string) {
 	p, ok := g.players.Get(id)
 	if !ok {
-		log.Println("client not found:", id)
+		g.logger().Warn("client not found", "client_id", id)
 		return
 	}
 
 	// 월드 데이터 전송
-	ev := model.Event{
-		Type:    model.EVENT_TYPE_GAME_INIT,
-		OwnerId: g.id,
-		Data: model.EventData{
-			X:         g.worldSize,
-			Y:         g.worldMinSize,
-			MoveSpeed: g.worldSpeed,
-		},
-	}
-	p.Client.AddMsg(model.MakeMsg(id, model.MSG_TYPE_INGAME, ev))
+	p.Client.AddMsg(model.MakeMsg(id, model.MSG_TYPE_INGAME, g.makeZoneEvent(model.EVENT_TYPE_GAME_INIT)))
+
+	// 장애물 데이터 전송
+	g.obstacles.Range(func(oid string, o *Obstacle) bool {
+		p.Client.AddMsg(model.MakeMsg(id, model.MSG_TYPE_INGAME, g.makeObstacleEvent(model.EVENT_TYPE_OBSTACLE_CREATE, o)))
+		return true
+	})
 
 	// 플레이어 데이터 전송
 	g.players.Range(func(pid string, player *Player) bool {
+		skin := player.Skin
 		ev := model.Event{
 			Type:    model.EVENT_TYPE_PLAYER_CREATE,
 			OwnerId: pid,
 			Data: model.EventData{
 				Idx: player.Idx, X: player.X, Y: player.Y, Angle: player.Angle,
 				MoveSpeed: player.MoveSpeed, RotateSpeed: player.RotateSpeed,
+				VX: player.VX, VY: player.VY, VR: player.VR,
+				FlightModel: player.FlightModel,
+				Name:        player.Name, Skin: &skin,
+				Team: player.Team,
 			},
 		}
 		p.Client.AddMsg(model.MakeMsg(pid, model.MSG_TYPE_INGAME, ev))
@@ -285,38 +764,62 @@ func (g *Game) eventHandler() {
 	for {
 		select {
 		case ev := <-g.eventRecvChan:
+			// 연결이 끊긴 플레이어는 이미 플레이어 목록에서 삭제되었으므로 별도로 처리
+			if ev.Type == model.EVENT_TYPE_PLAYER_DISCONNECT {
+				g.disconnectPlayer(ev.OwnerId)
+				break
+			}
+
 			p, ok := g.players.Get(ev.OwnerId)
 			if !ok {
-				log.Println("player not found:", ev.OwnerId)
+				g.logger().Warn("player not found", "client_id", ev.OwnerId, "event_type", ev.Type)
 				break
 			}
 			switch ev.Type {
 			case model.EVENT_TYPE_GAME_INIT:
+				// 초기 데이터는 한 번만 전송
+				if p.Initialized {
+					g.violation(p, "duplicate game init")
+					break
+				}
+				p.Initialized = true
 				g.sendInitData(ev.OwnerId)
 
 			case model.EVENT_TYPE_PLAYER_MOVE:
-				// 해당 플레이어 이동 방향 업데이트
-				p.DirX = ev.Data.DirX
-				p.DirY = ev.Data.DirY
-				p.DirR = ev.Data.DirR
+				// 해당 플레이어 이동 방향 업데이트: 범위를 벗어난 값은 보정
+				var ok [3]bool
+				p.DirX, ok[0] = clampDir(ev.Data.DirX)
+				p.DirY, ok[1] = clampDir(ev.Data.DirY)
+				p.DirR, ok[2] = clampDir(ev.Data.DirR)
+				if !ok[0] || !ok[1] || !ok[2] {
+					g.violation(p, "invalid move direction")
+				}
 
 				// 해당 이벤트를 모든 플레이어에게 전파
-				ev.Data.Idx = p.Idx
-				ev.Data.X = p.X
-				ev.Data.Y = p.Y
-				ev.Data.Angle = p.Angle
-				g.eventSendChan <- ev
+				g.eventSendChan <- g.makePlayerMoveEvent(p)
 
 			case model.EVENT_TYPE_PLAYER_FIRE:
 				p.IsFire = true
 
-			case model.EVENT_TYPE_PLAYER_DISCONNECT:
-				// DEAD 처리하도록 다른 플레이어에게 전파
-				ev := model.Event{
-					Type:    model.EVENT_TYPE_PLAYER_DEAD,
-					OwnerId: ev.OwnerId,
+			case model.EVENT_TYPE_PLAYER_BOOST:
+				if p.BoostCooldown > 0 {
+					// 쿨다운 중인 경우 남은 쿨다운 시간 전송
+					p.Client.AddMsg(model.MakeMsg(p.Id, model.MSG_TYPE_INGAME, model.Event{
+						Type: model.EVENT_TYPE_PLAYER_BOOST_COOLDOWN, OwnerId: p.Id,
+						Data: model.EventData{Idx: p.Idx, Cooldown: p.BoostCooldown},
+					}))
+					break
 				}
-				g.eventSendChan <- ev
+				p.IsBoost = true
+
+			case model.EVENT_TYPE_PLAYER_EMOTE:
+				g.emote(p, ev)
+
+			case model.EVENT_TYPE_PLAYER_PING:
+				g.ping(p, ev)
+
+			default:
+				g.violation(p, "unknown event type: "+ev.Type)
 			}
 		default:
 			return
@@ -324,6 +827,25 @@ func (g *Game) eventHandler() {
 	}
 }
 
+// 이동 방향 값을 -1, 0, 1 범위로 보정하고, 원래 값이 범위 안에 있었는지 여부를 반환
+func clampDir(v int) (int, bool) {
+	if v < -1 {
+		return -1, false
+	} else if v > 1 {
+		return 1, false
+	}
+	return v, true
+}
+
+func (g *Game) disconnectPlayer(id string) {
+	p, ok := g.participants[id]
+	if !ok || p.IsDead {
+		return
+	}
+	// DEAD 처리하도록 다른 플레이어에게 전파
+	g.killPlayer(p, "", GAME_WEAPON_NONE)
+}
+
 func (g *Game) broadcastEvent() {
 	for {
 		select {
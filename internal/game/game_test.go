@@ -0,0 +1,106 @@
+package game
+
+import (
+	"space_arena/internal/model"
+	"testing"
+)
+
+// 장애물과 발사체 생성기가 없는 테스트용 게임: 플레이어 a가 발사하고 b가 피격됨
+func newTestGame(t *testing.T) (*Game, *Player) {
+	t.Helper()
+	clients := []*model.Client{model.CreateClient("a", nil), model.CreateClient("b", nil)}
+	m := &Map{
+		Name:     "test",
+		Boundary: MapBoundary{Shape: MAP_BOUNDARY_SHAPE_CIRCLE, Size: 20},
+		Spawns:   []MapSpawn{{X: 0, Y: 8}, {X: 0, Y: -8}},
+	}
+	g := NewGame("test", clients, m, nil)
+	target, _ := g.players.Get("b")
+	return g, target
+}
+
+// 원점에서 +x 방향으로 발사된 레이저: 한 스텝에 16px 이동
+func fireLaser(g *Game, owner string) *Projectile {
+	prj := CreateProjectile(owner, GAME_PROJECTILE_TYPE_LASER, 0, 0, 0)
+	g.projectiles.Set(prj.Id, prj)
+	return prj
+}
+
+func step(g *Game, n int) {
+	for i := 0; i < n; i++ {
+		g.update(1.0 / GAME_TICK_RATE)
+	}
+}
+
+func TestLaserStepDistance(t *testing.T) {
+	if d := GAME_PROJECTILE_SPEED_LASER / GAME_TICK_RATE; d != 16 {
+		t.Fatalf("laser moves %vpx per step, tests below assume 16px", d)
+	}
+}
+
+// 스텝 끝 위치만 검사하면 x=16, x=32에서 모두 충돌 범위(13.2px) 밖이지만, 경로는 플레이어를 지나감
+func TestLaserDoesNotPassThroughPlayer(t *testing.T) {
+	g, target := newTestGame(t)
+	target.X, target.Y = 24, 12
+	prj := fireLaser(g, "a")
+
+	step(g, 3)
+	if !target.IsDead {
+		t.Fatalf("laser passed through player, projectile at (%v, %v)", prj.X, prj.Y)
+	}
+	if target.KillerId != "a" {
+		t.Errorf("KillerId = %q, want %q", target.KillerId, "a")
+	}
+	if _, ok := g.projectiles.Get(prj.Id); ok {
+		t.Error("projectile not removed after hit")
+	}
+}
+
+// 반지름 2px 장애물은 스텝 끝 위치(x=16, x=32)에서 모두 8px 떨어져 있어 경로 검사 없이는 통과함
+func TestLaserDoesNotPassThroughObstacle(t *testing.T) {
+	g, target := newTestGame(t)
+	target.X, target.Y = 0, 200
+	rock := CreateObstacle(GAME_OBSTACLE_TYPE_ROCK, 24, 0, 2, 0, 0)
+	g.obstacles.Set(rock.Id, rock)
+	prj := fireLaser(g, "a")
+
+	step(g, 3)
+	if _, ok := g.projectiles.Get(prj.Id); ok {
+		t.Fatalf("laser passed through obstacle, projectile at (%v, %v)", prj.X, prj.Y)
+	}
+}
+
+// 레이저가 (0, 0)→(16, 0)으로 이동하는 동안 플레이어는 (14, -2)→(14, 14)로 이동
+// 스텝 중간에는 8.5px까지 가까워지지만, 이동 후 플레이어 위치 기준으로는 레이저 경로와 14px 떨어짐
+func TestLaserHitsMovingPlayer(t *testing.T) {
+	g, target := newTestGame(t)
+	target.X, target.Y, target.Angle = 14, -2, 0
+	target.DirY = 1
+	target.MoveSpeed = GAME_PROJECTILE_SPEED_LASER
+	prj := fireLaser(g, "a")
+
+	step(g, 1)
+	if target.Y != 14 {
+		t.Fatalf("target moved to (%v, %v), want (14, 14)", target.X, target.Y)
+	}
+	if !target.IsDead {
+		t.Fatalf("moving player not hit, projectile at (%v, %v)", prj.X, prj.Y)
+	}
+}
+
+// 같은 스텝에서 장애물(t=0.3)과 플레이어(t≈0.93)에 모두 닿으면 먼저 닿는 장애물이 막음
+func TestObstacleBlocksShot(t *testing.T) {
+	g, target := newTestGame(t)
+	target.X, target.Y = 28, 0
+	rock := CreateObstacle(GAME_OBSTACLE_TYPE_ROCK, 8, 0, 2, 0, 0)
+	g.obstacles.Set(rock.Id, rock)
+	prj := fireLaser(g, "a")
+
+	step(g, 1)
+	if target.IsDead {
+		t.Fatal("player hit through obstacle")
+	}
+	if _, ok := g.projectiles.Get(prj.Id); ok {
+		t.Fatalf("projectile not absorbed by obstacle, at (%v, %v)", prj.X, prj.Y)
+	}
+}
@@ -0,0 +1,43 @@
+package game
+
+import (
+	"math/rand"
+	"space_arena/internal/utils"
+)
+
+type HazardEmitter struct {
+	ProjectileType int
+	X              float64
+	Y              float64
+	Cooldown       float64
+	CooldownMin    float64
+	CooldownMax    float64
+	CountMin       int
+	CountMax       int
+	MaxProjectiles int
+}
+
+func CreateHazardEmitter(h MapHazard) *HazardEmitter {
+	e := HazardEmitter{
+		ProjectileType: mapHazardTypes[h.Type],
+		X:              h.X * GAME_OBJECT_WIDTH,
+		Y:              h.Y * GAME_OBJECT_WIDTH,
+		Cooldown:       h.InitialDelay,
+		CooldownMin:    h.CooldownMin,
+		CooldownMax:    h.CooldownMax,
+		CountMin:       h.CountMin,
+		CountMax:       h.CountMax,
+		MaxProjectiles: h.MaxProjectiles,
+	}
+	return &e
+}
+
+// 쿨다운을 업데이트하고, 이번에 발사할 발사체 개수를 반환
+func (e *HazardEmitter) Update(dt float64, numProjectiles int) int {
+	e.Cooldown -= dt
+	if e.Cooldown > 0 || numProjectiles >= e.MaxProjectiles {
+		return 0
+	}
+	e.Cooldown = utils.RandRange(e.CooldownMin, e.CooldownMax)
+	return rand.Intn(e.CountMax-e.CountMin+1) + e.CountMin
+}
@@ -0,0 +1,255 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// 맵 파일의 좌표와 크기는 GAME_OBJECT_WIDTH 단위, 각도는 degree 단위로 작성
+const (
+	MAP_BOUNDARY_SHAPE_CIRCLE  = "circle"
+	MAP_HAZARD_TYPE_ENERGYBALL = "energyball"
+	MAP_DEFAULT_MAX_PLAYERS    = 9
+)
+
+var mapObstacleTypes = map[string]int{
+	"rock":     GAME_OBSTACLE_TYPE_ROCK,
+	"crystal":  GAME_OBSTACLE_TYPE_CRYSTAL,
+	"asteroid": GAME_OBSTACLE_TYPE_ASTEROID,
+}
+
+var mapHazardTypes = map[string]int{
+	MAP_HAZARD_TYPE_ENERGYBALL: GAME_PROJECTILE_TYPE_ENERGYBALL,
+}
+
+type Map struct {
+	Name          string         `json:"name"`
+	MaxPlayers    int            `json:"max_players"`
+	RammingDamage bool           `json:"ramming_damage"` // 우주선 충돌 시 피해 여부
+	FlightModel   string         `json:"flight_model"`   // 비행 모델(arcade, newtonian), 기본값 arcade
+	Boundary      MapBoundary    `json:"boundary"`
+	Spawns        []MapSpawn     `json:"spawns"` // 비어 있으면 월드 영역 경계에 균등하게 배치
+	Obstacles     []MapObstacle  `json:"obstacles"`
+	Hazards       []MapHazard    `json:"hazards"`
+	Zone          []MapZonePhase `json:"zone"`
+}
+
+type MapBoundary struct {
+	Shape string  `json:"shape"`
+	Size  float64 `json:"size"`
+}
+
+type MapSpawn struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Angle float64 `json:"angle"`
+}
+
+type MapObstacle struct {
+	Type  string  `json:"type"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	R     float64 `json:"r"`
+	Angle float64 `json:"angle"`
+	Speed float64 `json:"speed"`
+}
+
+type MapHazard struct {
+	Type           string  `json:"type"`
+	X              float64 `json:"x"`
+	Y              float64 `json:"y"`
+	InitialDelay   float64 `json:"initial_delay"`   // 첫 발사까지 대기 시간(sec)
+	CooldownMin    float64 `json:"cooldown_min"`    // 발사 간격 최소값(sec)
+	CooldownMax    float64 `json:"cooldown_max"`    // 발사 간격 최대값(sec)
+	CountMin       int     `json:"count_min"`       // 한 번에 발사하는 발사체 최소 개수
+	CountMax       int     `json:"count_max"`       // 한 번에 발사하는 발사체 최대 개수
+	MaxProjectiles int     `json:"max_projectiles"` // 월드에 발사체가 이 개수 이상이면 발사하지 않음
+}
+
+type MapZonePhase struct {
+	Wait  float64 `json:"wait"`  // 이전 단계 종료 후 축소 시작까지 대기 시간(sec)
+	Size  float64 `json:"size"`  // 축소 목표 크기
+	Speed float64 `json:"speed"` // 축소 속도(per sec)
+}
+
+// 맵 파일이 없는 경우 사용하는 기본 맵
+func DefaultMap() *Map {
+	return &Map{
+		Name:        "classic",
+		MaxPlayers:  MAP_DEFAULT_MAX_PLAYERS,
+		FlightModel: FLIGHT_MODEL_ARCADE,
+		Boundary:    MapBoundary{Shape: MAP_BOUNDARY_SHAPE_CIRCLE, Size: 9},
+		Obstacles: []MapObstacle{
+			{Type: "rock", X: 4, Y: 0, R: 0.75},
+			{Type: "rock", X: -4, Y: 0, R: 0.75},
+			{Type: "crystal", X: 0, Y: 4, R: 0.5},
+			{Type: "crystal", X: 0, Y: -4, R: 0.5},
+			{Type: "asteroid", X: 5, Y: 5, R: 1, Angle: 135, Speed: 0.5},
+			{Type: "asteroid", X: -5, Y: -5, R: 1, Angle: -45, Speed: 0.5},
+		},
+		Hazards: []MapHazard{
+			{
+				Type: MAP_HAZARD_TYPE_ENERGYBALL, InitialDelay: 5,
+				CooldownMin: 0.25, CooldownMax: 1.5, CountMin: 5, CountMax: 14, MaxProjectiles: 50,
+			},
+		},
+		Zone: []MapZonePhase{
+			{Wait: 0, Size: 2, Speed: 0.05},
+		},
+	}
+}
+
+func LoadMap(path string) (*Map, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadMap: %w", err)
+	}
+
+	m := Map{MaxPlayers: MAP_DEFAULT_MAX_PLAYERS, FlightModel: FLIGHT_MODEL_ARCADE}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("LoadMap: %s: %w", path, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("LoadMap: %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// 디렉토리 내의 모든 맵 파일(*.json)을 이름순으로 로드
+func LoadMaps(dir string) ([]*Map, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("LoadMaps: %w", err)
+	}
+	sort.Strings(paths)
+
+	maps := []*Map{}
+	for _, path := range paths {
+		m, err := LoadMap(path)
+		if err != nil {
+			return nil, err
+		}
+		maps = append(maps, m)
+	}
+	return maps, nil
+}
+
+func (m *Map) Validate() error {
+	if m.Name == "" {
+		return fmt.Errorf("map name is empty")
+	}
+	if m.MaxPlayers <= 0 {
+		return fmt.Errorf("max_players must be positive: %d", m.MaxPlayers)
+	}
+
+	// 비행 모델
+	if m.FlightModel != FLIGHT_MODEL_ARCADE && m.FlightModel != FLIGHT_MODEL_NEWTONIAN {
+		return fmt.Errorf("unknown flight model: %q", m.FlightModel)
+	}
+
+	// 월드 영역
+	if m.Boundary.Shape != MAP_BOUNDARY_SHAPE_CIRCLE {
+		return fmt.Errorf("unsupported boundary shape: %q", m.Boundary.Shape)
+	}
+	if m.Boundary.Size <= 0 {
+		return fmt.Errorf("boundary size must be positive: %v", m.Boundary.Size)
+	}
+	inBoundary := func(x, y float64) bool {
+		return math.Hypot(x, y) <= m.Boundary.Size
+	}
+
+	// 스폰 위치
+	if len(m.Spawns) > 0 && len(m.Spawns) < m.MaxPlayers {
+		return fmt.Errorf("not enough spawns: %d < max_players %d", len(m.Spawns), m.MaxPlayers)
+	}
+	for i, s := range m.Spawns {
+		if !inBoundary(s.X, s.Y) {
+			return fmt.Errorf("spawns[%d] is out of boundary", i)
+		}
+	}
+
+	// 장애물
+	for i, o := range m.Obstacles {
+		if _, ok := mapObstacleTypes[o.Type]; !ok {
+			return fmt.Errorf("obstacles[%d]: unknown type %q", i, o.Type)
+		}
+		if o.R <= 0 {
+			return fmt.Errorf("obstacles[%d]: r must be positive", i)
+		}
+		if o.Speed < 0 {
+			return fmt.Errorf("obstacles[%d]: speed must not be negative", i)
+		}
+		if !inBoundary(o.X, o.Y) {
+			return fmt.Errorf("obstacles[%d] is out of boundary", i)
+		}
+	}
+
+	// 위험 요소 발생기
+	for i, h := range m.Hazards {
+		if _, ok := mapHazardTypes[h.Type]; !ok {
+			return fmt.Errorf("hazards[%d]: unknown type %q", i, h.Type)
+		}
+		if h.InitialDelay < 0 || h.CooldownMin <= 0 || h.CooldownMax < h.CooldownMin {
+			return fmt.Errorf("hazards[%d]: invalid delay or cooldown range", i)
+		}
+		if h.CountMin <= 0 || h.CountMax < h.CountMin {
+			return fmt.Errorf("hazards[%d]: invalid count range", i)
+		}
+		if h.MaxProjectiles <= 0 {
+			return fmt.Errorf("hazards[%d]: max_projectiles must be positive", i)
+		}
+		if !inBoundary(h.X, h.Y) {
+			return fmt.Errorf("hazards[%d] is out of boundary", i)
+		}
+	}
+
+	// 월드 영역 축소 일정
+	size := m.Boundary.Size
+	for i, z := range m.Zone {
+		if z.Wait < 0 {
+			return fmt.Errorf("zone[%d]: wait must not be negative", i)
+		}
+		if z.Size <= 0 || z.Size >= size {
+			return fmt.Errorf("zone[%d]: size must be positive and smaller than the previous size", i)
+		}
+		if z.Speed <= 0 {
+			return fmt.Errorf("zone[%d]: speed must be positive", i)
+		}
+		size = z.Size
+	}
+	return nil
+}
+
+// 플레이어 인덱스에 해당하는 스폰 위치 반환
+func (m *Map) spawn(idx, numPlayers int) (float64, float64, float64) {
+	if len(m.Spawns) > 0 {
+		s := m.Spawns[idx%len(m.Spawns)]
+		return s.X * GAME_OBJECT_WIDTH, s.Y * GAME_OBJECT_WIDTH, degToRad(s.Angle)
+	}
+
+	// 월드 영역 경계에서 스폰되도록 위치 계산
+	angle := 2 * math.Pi * float64(idx) / float64(numPlayers)
+	size := m.Boundary.Size * GAME_OBJECT_WIDTH
+	return size * math.Cos(angle), size * math.Sin(angle), angle - math.Pi/2
+}
+
+func (m *Map) createObstacles() []*Obstacle {
+	obstacles := []*Obstacle{}
+	for _, o := range m.Obstacles {
+		obstacles = append(obstacles, CreateObstacle(mapObstacleTypes[o.Type],
+			o.X*GAME_OBJECT_WIDTH, o.Y*GAME_OBJECT_WIDTH, o.R*GAME_OBJECT_WIDTH,
+			degToRad(o.Angle), o.Speed*GAME_OBJECT_WIDTH))
+	}
+	return obstacles
+}
+
+func degToRad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
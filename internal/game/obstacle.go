@@ -0,0 +1,132 @@
+package game
+
+import (
+	"math"
+	"space_arena/internal/utils"
+)
+
+const (
+	GAME_OBSTACLE_TYPE_ROCK     = 0 // 암석: 파괴 불가, 발사체 흡수
+	GAME_OBSTACLE_TYPE_CRYSTAL  = 1 // 크리스탈: 파괴 불가, 발사체 반사
+	GAME_OBSTACLE_TYPE_ASTEROID = 2 // 소행성: 파괴 가능, 피격 시 분열
+)
+
+const (
+	GAME_OBSTACLE_ASTEROID_HP         = 3                     // 소행성 내구도
+	GAME_OBSTACLE_ASTEROID_MIN_RADIUS = GAME_OBJECT_WIDTH / 4 // 분열 가능한 최소 반지름
+	GAME_OBSTACLE_ASTEROID_MAX_SPEED  = GAME_OBJECT_WIDTH * 2 // 분열된 소행성의 최대 이동 속도
+	GAME_OBSTACLE_SPLIT_ANGLE         = math.Pi / 4           // 분열된 소행성의 진행 방향 변화량
+)
+
+type Obstacle struct {
+	Id        string
+	Type      int
+	X         float64
+	Y         float64
+	R         float64
+	Angle     float64
+	MoveSpeed float64
+	Hp        int
+}
+
+func CreateObstacle(t int, x, y, r, angle, moveSpeed float64) *Obstacle {
+	o := Obstacle{
+		Id:        utils.RandomCapAlphaNumeric(10),
+		Type:      t,
+		X:         x,
+		Y:         y,
+		R:         r,
+		Angle:     angle,
+		MoveSpeed: moveSpeed,
+	}
+
+	if t == GAME_OBSTACLE_TYPE_ASTEROID {
+		o.Hp = GAME_OBSTACLE_ASTEROID_HP
+	}
+
+	return &o
+}
+
+func (o *Obstacle) Update(dt float64) {
+	if o.MoveSpeed == 0 {
+		return
+	}
+	o.X += math.Cos(o.Angle) * o.MoveSpeed * dt
+	o.Y += math.Sin(o.Angle) * o.MoveSpeed * dt
+}
+
+// 월드 영역 경계에 닿은 경우 반사시키고, 반사 여부를 반환
+func (o *Obstacle) Bounce(worldSize float64) bool {
+	dist := math.Hypot(o.X, o.Y)
+	if o.MoveSpeed == 0 || dist == 0 || dist+o.R <= worldSize {
+		return false
+	}
+
+	// 경계 안쪽으로 위치 보정
+	nx, ny := o.X/dist, o.Y/dist
+	inner := math.Max(worldSize-o.R, 0)
+	o.X = nx * inner
+	o.Y = ny * inner
+
+	// 바깥쪽으로 향하는 경우에만 진행 방향 반사
+	vx, vy := math.Cos(o.Angle), math.Sin(o.Angle)
+	dot := vx*nx + vy*ny
+	if dot > 0 {
+		o.Angle = math.Atan2(vy-2*dot*ny, vx-2*dot*nx)
+	}
+	return true
+}
+
+func (o *Obstacle) IsDestructible() bool {
+	return o.Type == GAME_OBSTACLE_TYPE_ASTEROID
+}
+
+// 파괴된 소행성을 두 개의 작은 소행성으로 분열
+func (o *Obstacle) Split() []*Obstacle {
+	r := o.R / 2
+	if r < GAME_OBSTACLE_ASTEROID_MIN_RADIUS {
+		return nil
+	}
+	speed := math.Min(math.Max(o.MoveSpeed*1.5, GAME_OBJECT_WIDTH*0.5), GAME_OBSTACLE_ASTEROID_MAX_SPEED)
+
+	children := []*Obstacle{}
+	for _, sign := range []float64{-1, 1} {
+		angle := o.Angle + sign*GAME_OBSTACLE_SPLIT_ANGLE
+		x := o.X + math.Cos(angle)*r
+		y := o.Y + math.Sin(angle)*r
+		children = append(children, CreateObstacle(o.Type, x, y, r, angle, speed))
+	}
+	return children
+}
+
+// 원형 오브젝트를 장애물 바깥으로 밀어내고, 충돌 여부를 반환
+func (o *Obstacle) PushOut(x, y, r float64) (float64, float64, bool) {
+	if !utils.CircleCollision(x, y, r, o.X, o.Y, o.R) {
+		return x, y, false
+	}
+	dx, dy := x-o.X, y-o.Y
+	dist := math.Hypot(dx, dy)
+	if dist == 0 {
+		dx, dy, dist = 1, 0, 1
+	}
+	scale := (o.R + r) / dist
+	return o.X + dx*scale, o.Y + dy*scale, true
+}
+
+// 발사체 진행 방향을 장애물 표면 기준으로 반사
+func (o *Obstacle) Deflect(prj *Projectile) {
+	x, y, _ := o.PushOut(prj.X, prj.Y, prj.W/2)
+	prj.X, prj.Y = x, y
+
+	nx, ny := x-o.X, y-o.Y
+	n := math.Hypot(nx, ny)
+	if n == 0 {
+		return
+	}
+	nx, ny = nx/n, ny/n
+	vx, vy := math.Cos(prj.Angle), math.Sin(prj.Angle)
+	dot := vx*nx + vy*ny
+	if dot < 0 {
+		prj.Angle = math.Atan2(vy-2*dot*ny, vx-2*dot*nx)
+	}
+}
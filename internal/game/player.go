@@ -3,54 +3,204 @@ package game
 import (
 	"math"
 	"space_arena/internal/model"
+	"space_arena/internal/utils"
 )
 
 const (
-	PLAYER_FIRE_COOLDOWN = 1.5
-	PLAYER_MOVE_SPEED    = GAME_OBJECT_WIDTH * 2.5
-	PLAYER_ROTATE_SPEED  = 1
+	PLAYER_FIRE_COOLDOWN      = 1.5
+	PLAYER_BOOST_COOLDOWN     = 5
+	PLAYER_BOOST_DURATION     = 0.25                  // 대시 지속 시간(sec)
+	PLAYER_BOOST_SPEED        = GAME_OBJECT_WIDTH * 8 // 대시 속도
+	PLAYER_BOOST_INVULNERABLE = 0.3                   // 대시 후 무적 시간(sec)
+	PLAYER_MOVE_SPEED         = GAME_OBJECT_WIDTH * 2.5
+	PLAYER_ROTATE_SPEED       = 1
+	PLAYER_SYNC_COOLDOWN      = 0.1
+	PLAYER_LATENCY_INTERVAL   = 1 // 플레이어에게 지연 시간을 알려주는 주기(sec)
+	PLAYER_SKIN_NUM           = 9 // 클라이언트의 우주선 색상 수와 동일해야 함
+)
+
+const (
+	PLAYER_COLLISION_RADIUS = GAME_OBJECT_WIDTH / 4
+	PLAYER_INERTIA_DECAY    = 3                     // 관성 속도 감쇠율(per sec)
+	PLAYER_INERTIA_MIN      = 1                     // 이 속도 미만의 관성은 제거
+	PLAYER_KNOCKBACK_MIN    = GAME_OBJECT_WIDTH * 2 // 충돌 시 최소 넉백 속도
+	PLAYER_RAM_SPEED        = GAME_OBJECT_WIDTH * 2 // 충돌 피해가 발생하는 최소 충돌 속도
+)
+
+// 플레이어를 탈락시킨 공격 종류
+const (
+	GAME_WEAPON_NONE       = -1 // 연결 해제
+	GAME_WEAPON_LASER      = GAME_PROJECTILE_TYPE_LASER
+	GAME_WEAPON_ENERGYBALL = GAME_PROJECTILE_TYPE_ENERGYBALL
+	GAME_WEAPON_RAM        = 2 // 우주선 충돌
+)
+
+const (
+	FLIGHT_MODEL_ARCADE    = "arcade"    // 입력 방향으로 일정한 속도로 이동
+	FLIGHT_MODEL_NEWTONIAN = "newtonian" // 추력에 의한 가속과 항력에 의한 감속
+)
+
+// newtonian 비행 모델 상수
+const (
+	PLAYER_THRUST         = GAME_OBJECT_WIDTH * 5 // 추력에 의한 가속도(per sec^2)
+	PLAYER_DRAG           = 1.5                   // 항력 계수(per sec)
+	PLAYER_MAX_VELOCITY   = GAME_OBJECT_WIDTH * 4 // 최대 속도
+	PLAYER_ANGULAR_THRUST = 4                     // 회전 가속도(rad/sec^2)
+	PLAYER_ANGULAR_DRAG   = 3                     // 회전 항력 계수(per sec)
+	PLAYER_ANGULAR_MIN    = 0.01                  // 이 각속도 미만의 회전은 제거
 )
 
 type Player struct {
-	Id  string
-	Idx int
+	Id   string
+	Idx  int
+	Team int // 같은 팀끼리는 피해를 주지 않음, 개인전에서는 플레이어마다 다른 팀
+	Name string
+	Skin int
 	// MsgChan      chan model.Msg
-	Client       *model.Client
-	X            float64
-	Y            float64
-	W            float64
-	H            float64
-	DirX         int // 0: 이동 없음, 1: 오른쪽 방향, -1: 왼쪽 방향
-	DirY         int // 0: 이동 없음, 1: 위쪽 방향, -1: 아래쪽 방향
-	DirR         int // 0: 회전 없음, 1: 오른쪽 방향, -1: 왼쪽 방향
-	Angle        float64
-	MoveSpeed    float64
-	RotateSpeed  float64
-	IsFire       bool
-	FireCooldown float64
-	IsDead       bool
+	Client           *model.Client
+	X                float64
+	Y                float64
+	W                float64
+	H                float64
+	DirX             int // 0: 이동 없음, 1: 오른쪽 방향, -1: 왼쪽 방향
+	DirY             int // 0: 이동 없음, 1: 위쪽 방향, -1: 아래쪽 방향
+	DirR             int // 0: 회전 없음, 1: 오른쪽 방향, -1: 왼쪽 방향
+	Angle            float64
+	VX               float64 // 관성 속도 x
+	VY               float64 // 관성 속도 y
+	VR               float64 // 각속도(newtonian 비행 모델)
+	FlightModel      string
+	MoveSpeed        float64
+	RotateSpeed      float64
+	IsFire           bool
+	FireCooldown     float64
+	IsBoost          bool
+	BoostCooldown    float64
+	BoostTime        float64 // 남은 대시 시간(sec)
+	InvulnerableTime float64 // 남은 무적 시간(sec)
+	IsDead           bool
+	KillerId         string             // 이 플레이어를 탈락시킨 오브젝트의 소유자 아이디
+	Kills            int                // 이번 게임에서 탈락시킨 플레이어 수
+	Placement        int                // 최종 순위
+	SurvivalTime     float64            // 생존 시간(sec)
+	ShotsFired       int                // 레이저 발사 횟수
+	ShotsHit         int                // 레이저 명중 횟수
+	SyncCooldown     float64            // 위치 동기화 이벤트 전송 쿨다운 시간(sec)
+	SignalLimiter    *utils.TokenBucket // 이모트 및 핑 빈도 제한
+	Initialized      bool               // 초기 데이터 전송 여부
 }
 
 func CreatePlayer(id string, idx int, c *model.Client, x, y, angle float64) *Player {
 	p := Player{
-		Id: id, Idx: idx, Client: c,
+		Id: id, Idx: idx, Team: idx, Client: c, Skin: idx % PLAYER_SKIN_NUM,
 		X: x, Y: y, W: GAME_OBJECT_WIDTH, H: GAME_OBJECT_HEIGHT,
 		Angle: angle, MoveSpeed: PLAYER_MOVE_SPEED, RotateSpeed: PLAYER_ROTATE_SPEED,
+		FlightModel:   FLIGHT_MODEL_ARCADE,
+		SignalLimiter: utils.NewTokenBucket(PLAYER_SIGNAL_RATE, PLAYER_SIGNAL_BURST),
 	}
 	return &p
 }
 
-func (p *Player) Update(dt float64) {
+// 이동 및 회전을 업데이트하고, 장애물에 막혔는지 여부를 반환
+func (p *Player) Update(dt float64, obstacles []*Obstacle) bool {
+	if p.FlightModel == FLIGHT_MODEL_NEWTONIAN {
+		p.updateNewtonian(dt)
+	} else {
+		p.updateArcade(dt)
+	}
+
+	// 장애물과 겹치지 않도록 위치 보정
+	blocked := false
+	for _, o := range obstacles {
+		x, y, hit := o.PushOut(p.X, p.Y, PLAYER_COLLISION_RADIUS)
+		if hit {
+			p.X, p.Y = x, y
+			blocked = true
+		}
+	}
+	return blocked
+}
+
+func (p *Player) updateArcade(dt float64) {
 	p.Angle = p.Angle + p.RotateSpeed*dt*float64(p.DirR)
+	vx, vy := p.inputVelocity()
+	p.X += (vx + p.VX) * dt
+	p.Y += (vy + p.VY) * dt
+
+	// 관성 속도 감쇠
+	decay := math.Exp(-PLAYER_INERTIA_DECAY * dt)
+	p.VX *= decay
+	p.VY *= decay
+	if math.Hypot(p.VX, p.VY) < PLAYER_INERTIA_MIN {
+		p.VX, p.VY = 0, 0
+	}
+}
+
+func (p *Player) updateNewtonian(dt float64) {
+	// 회전: 회전 입력으로 각속도 가속, 최대 각속도는 RotateSpeed
+	p.VR += PLAYER_ANGULAR_THRUST * dt * float64(p.DirR)
+	p.VR *= math.Exp(-PLAYER_ANGULAR_DRAG * dt)
+	p.VR = math.Max(-p.RotateSpeed, math.Min(p.VR, p.RotateSpeed))
+	if p.DirR == 0 && math.Abs(p.VR) < PLAYER_ANGULAR_MIN {
+		p.VR = 0
+	}
+	p.Angle += p.VR * dt
+
+	// 이동: 입력 방향으로 추력을 가하고 항력으로 감속
+	ax, ay := p.inputDirection()
+	p.VX += ax * PLAYER_THRUST * dt
+	p.VY += ay * PLAYER_THRUST * dt
+	drag := math.Exp(-PLAYER_DRAG * dt)
+	p.VX *= drag
+	p.VY *= drag
+	maxVelocity := float64(PLAYER_MAX_VELOCITY)
+	if p.BoostTime > 0 {
+		maxVelocity += PLAYER_BOOST_SPEED
+	}
+	speed := math.Hypot(p.VX, p.VY)
+	if speed > maxVelocity {
+		p.VX *= maxVelocity / speed
+		p.VY *= maxVelocity / speed
+	} else if ax == 0 && ay == 0 && speed < PLAYER_INERTIA_MIN {
+		p.VX, p.VY = 0, 0
+	}
+	p.X += p.VX * dt
+	p.Y += p.VY * dt
+}
+
+// 입력 방향의 단위 벡터
+func (p *Player) inputDirection() (float64, float64) {
 	vx := math.Cos(p.Angle)*float64(p.DirX) + math.Cos(p.Angle+math.Pi/2)*float64(p.DirY)
 	vy := math.Sin(p.Angle)*float64(p.DirX) + math.Sin(p.Angle+math.Pi/2)*float64(p.DirY)
 	len := math.Hypot(vx, vy)
-	if len > 0 {
-		vx /= len
-		vy /= len
-		p.X += vx * p.MoveSpeed * dt
-		p.Y += vy * p.MoveSpeed * dt
+	if len == 0 {
+		return 0, 0
+	}
+	return vx / len, vy / len
+}
+
+// arcade 비행 모델에서 입력 방향에 따른 이동 속도
+func (p *Player) inputVelocity() (float64, float64) {
+	if p.FlightModel == FLIGHT_MODEL_NEWTONIAN {
+		return 0, 0
 	}
+	vx, vy := p.inputDirection()
+	return vx * p.MoveSpeed, vy * p.MoveSpeed
+}
+
+// 입력 이동 속도와 관성 속도를 합한 현재 속도
+func (p *Player) Velocity() (float64, float64) {
+	vx, vy := p.inputVelocity()
+	return vx + p.VX, vy + p.VY
+}
+
+func (p *Player) HasInertia() bool {
+	return p.VX != 0 || p.VY != 0 || p.VR != 0
+}
+
+func (p *Player) AddImpulse(vx, vy float64) {
+	p.VX += vx
+	p.VY += vy
 }
 
 func (p *Player) CheckFire(dt float64) bool {
@@ -67,3 +217,36 @@ func (p *Player) CheckFire(dt float64) bool {
 
 	return false
 }
+
+// 대시 쿨다운을 업데이트하고, 대시 발동 여부와 이번에 쿨다운이 끝났는지 여부를 반환
+func (p *Player) CheckBoost(dt float64) (bool, bool) {
+	p.BoostTime = math.Max(p.BoostTime-dt, 0)
+	p.InvulnerableTime = math.Max(p.InvulnerableTime-dt, 0)
+
+	cooling := p.BoostCooldown > 0
+	p.BoostCooldown = math.Max(p.BoostCooldown-dt, 0)
+	if p.IsBoost && p.BoostCooldown <= 0 {
+		p.IsBoost = false
+		p.boost()
+		return true, false
+	}
+	p.IsBoost = false
+
+	return false, cooling && p.BoostCooldown <= 0
+}
+
+// 입력 방향(입력이 없으면 전방)으로 대시
+func (p *Player) boost() {
+	dx, dy := p.inputDirection()
+	if dx == 0 && dy == 0 {
+		dx, dy = math.Cos(p.Angle-math.Pi/2), math.Sin(p.Angle-math.Pi/2)
+	}
+	p.AddImpulse(dx*PLAYER_BOOST_SPEED, dy*PLAYER_BOOST_SPEED)
+	p.BoostCooldown = PLAYER_BOOST_COOLDOWN
+	p.BoostTime = PLAYER_BOOST_DURATION
+	p.InvulnerableTime = PLAYER_BOOST_INVULNERABLE
+}
+
+func (p *Player) IsInvulnerable() bool {
+	return p.InvulnerableTime > 0
+}
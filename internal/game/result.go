@@ -0,0 +1,83 @@
+package game
+
+import (
+	"sort"
+	"time"
+)
+
+type MatchResult struct {
+	GameId    string
+	MapName   string
+	Mode      string
+	StartedAt time.Time
+	Duration  time.Duration
+	Players   []PlayerResult // 순위순 정렬
+}
+
+type PlayerResult struct {
+	Id            string
+	Name          string
+	Authenticated bool
+	Team          int
+	Placement     int
+	Kills         int
+	ShotsFired    int
+	ShotsHit      int
+	SurvivalTime  float64
+	KillerId      string
+}
+
+// 게임 종료 후 결과 집계: Run이 반환된 후에 호출해야 함
+func (g *Game) Result() MatchResult {
+	lastPlacement := g.lastPlacement()
+	players := []PlayerResult{}
+	for _, p := range g.participants {
+		placement := p.Placement
+		if placement == 0 {
+			placement = lastPlacement
+		}
+		players = append(players, PlayerResult{
+			Id:            p.Id,
+			Name:          p.Name,
+			Authenticated: p.Client != nil && p.Client.Authenticated,
+			Team:          p.Team,
+			Placement:     placement,
+			Kills:         p.Kills,
+			ShotsFired:    p.ShotsFired,
+			ShotsHit:      p.ShotsHit,
+			SurvivalTime:  p.SurvivalTime,
+			KillerId:      p.KillerId,
+		})
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Placement != players[j].Placement {
+			return players[i].Placement < players[j].Placement
+		}
+		return g.participants[players[i].Id].Idx < g.participants[players[j].Id].Idx
+	})
+
+	return MatchResult{
+		GameId:    g.id,
+		MapName:   g.mapName,
+		Mode:      g.mode,
+		StartedAt: g.startedAt,
+		Duration:  g.endedAt.Sub(g.startedAt),
+		Players:   players,
+	}
+}
+
+// 순위가 정해지지 않은 팀의 순위: 게임 종료 전에 연결이 끊겨 탈락 처리가 되지 않은 경우 순위가 정해진 모든 팀보다 낮은 순위로 기록
+func (g *Game) lastPlacement() int {
+	teams := map[int]bool{}
+	for _, p := range g.participants {
+		placed := teams[p.Team]
+		teams[p.Team] = placed || p.Placement != 0
+	}
+	unplaced := 0
+	for _, placed := range teams {
+		if !placed {
+			unplaced++
+		}
+	}
+	return len(teams) - unplaced + 1
+}
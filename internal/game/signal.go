@@ -0,0 +1,64 @@
+package game
+
+import (
+	"math"
+	"space_arena/internal/model"
+)
+
+const (
+	PLAYER_EMOTE_NUM    = 8 // 이모트 종류 수
+	PLAYER_PING_NUM     = 3 // 핑 종류 수(0: 일반, 1: 위험, 2: 집결)
+	PLAYER_SIGNAL_RATE  = 1 // 초당 이모트 및 핑 수
+	PLAYER_SIGNAL_BURST = 3 // 연속으로 보낼 수 있는 이모트 및 핑 수
+)
+
+// 이모트 이벤트 처리: data.idx는 이모트 아이디, 같은 게임의 모든 플레이어에게 전파
+func (g *Game) emote(p *Player, ev model.Event) {
+	emoteId := ev.Data.Idx
+	if emoteId < 0 || emoteId >= PLAYER_EMOTE_NUM {
+		g.violation(p, "invalid emote")
+		return
+	}
+	if !p.SignalLimiter.Allow() {
+		p.Client.AddMsg(model.MakeErrorMsg(p.Id, "signal rate limited"))
+		return
+	}
+
+	g.eventSendChan <- model.Event{
+		Type: model.EVENT_TYPE_PLAYER_EMOTE, OwnerId: p.Id,
+		Data: model.EventData{Idx: emoteId, X: p.X, Y: p.Y},
+	}
+}
+
+// 핑 이벤트 처리: data.x, data.y는 월드 좌표, data.idx는 핑 종류
+// 생존한 플레이어만 월드 영역 안에 핑을 찍을 수 있으며, 팀전이면 같은 팀에게만 전송
+func (g *Game) ping(p *Player, ev model.Event) {
+	x, y, pingType := ev.Data.X, ev.Data.Y, ev.Data.Idx
+	if p.IsDead {
+		return
+	}
+	if pingType < 0 || pingType >= PLAYER_PING_NUM ||
+		math.IsNaN(x) || math.IsNaN(y) || math.Hypot(x, y) > g.worldSize {
+		g.violation(p, "invalid ping")
+		return
+	}
+	if !p.SignalLimiter.Allow() {
+		p.Client.AddMsg(model.MakeErrorMsg(p.Id, "signal rate limited"))
+		return
+	}
+
+	pingEv := model.Event{
+		Type: model.EVENT_TYPE_PLAYER_PING, OwnerId: p.Id,
+		Data: model.EventData{Idx: pingType, X: x, Y: y, Team: p.Team},
+	}
+	if g.mode != GAME_MODE_TEAM {
+		g.eventSendChan <- pingEv
+		return
+	}
+	g.players.Range(func(id string, player *Player) bool {
+		if player.Team == p.Team {
+			player.Client.AddMsg(model.MakeMsg(id, model.MSG_TYPE_INGAME, pingEv))
+		}
+		return true
+	})
+}
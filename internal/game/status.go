@@ -0,0 +1,28 @@
+package game
+
+import "time"
+
+// 진행 중인 게임 상태: 게임 루프와 다른 고루틴에서 조회할 수 있음
+type Status struct {
+	Id        string
+	Mode      string
+	MapName   string
+	Players   int           // 연결된 플레이어 수
+	Alive     int           // 생존한 플레이어 수
+	Tick      int64         // 처리한 틱 수
+	TickLag   time.Duration // 마지막 틱이 예정보다 늦게 시작된 시간
+	StartedAt time.Time
+}
+
+func (g *Game) Status() Status {
+	return Status{
+		Id:        g.id,
+		Mode:      g.mode,
+		MapName:   g.mapName,
+		Players:   g.players.Len(),
+		Alive:     g.playersAlive.Len(),
+		Tick:      g.tick.Load(),
+		TickLag:   time.Duration(g.tickLag.Load()),
+		StartedAt: g.startedAt,
+	}
+}
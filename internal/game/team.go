@@ -0,0 +1,44 @@
+package game
+
+import "space_arena/internal/model"
+
+// 팀별 생존 플레이어 수
+func (g *Game) aliveTeams() map[int]int {
+	teams := map[int]int{}
+	g.playersAlive.Range(func(id string, p *Player) bool {
+		if !p.IsDead {
+			teams[p.Team]++
+		}
+		return true
+	})
+	return teams
+}
+
+// 발사체 소유자가 플레이어와 같은 팀인지 여부: 월드에서 생성된 발사체는 소유자가 게임
+func (g *Game) isTeammate(ownerId string, p *Player) bool {
+	owner, ok := g.participants[ownerId]
+	return ok && owner.Team == p.Team
+}
+
+func (g *Game) setTeamPlacement(team, placement int) {
+	for _, p := range g.participants {
+		if p.Team == team {
+			p.Placement = placement
+		}
+	}
+}
+
+// 생존한 팀을 1위로 기록하고, 연결된 팀원 모두에게 승리 메시지 전송
+func (g *Game) victory() {
+	for team := range g.aliveTeams() {
+		g.setTeamPlacement(team, 1)
+	}
+	g.players.Range(func(id string, p *Player) bool {
+		if p.Placement == 1 {
+			p.Client.AddMsg(model.MakeMsg(p.Id, model.MSG_TYPE_INGAME, model.Event{
+				Type: model.EVENT_TYPE_GAME_VICTORY, OwnerId: p.Id,
+			}))
+		}
+		return true
+	})
+}
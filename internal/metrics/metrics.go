@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// 증가만 하는 누적 값
+type Counter struct {
+	v atomic.Int64
+}
+
+func (c *Counter) Inc() {
+	c.v.Add(1)
+}
+
+func (c *Counter) Add(n int64) {
+	c.v.Add(n)
+}
+
+func (c *Counter) Value() int64 {
+	return c.v.Load()
+}
+
+// 관측 값을 구간별로 누적하는 히스토그램
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64 // 구간 상한(오름차순)
+	counts  []uint64  // 구간별 관측 수(누적 아님)
+	sum     float64
+	count   uint64
+}
+
+func NewHistogram(buckets []float64) *Histogram {
+	b := append([]float64{}, buckets...)
+	sort.Float64s(b)
+	return &Histogram{buckets: b, counts: make([]uint64, len(b))}
+}
+
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sum += v
+	h.count++
+	i := sort.SearchFloat64s(h.buckets, v)
+	if i < len(h.counts) {
+		h.counts[i]++
+	}
+}
+
+// Prometheus 텍스트 형식으로 지표를 기록
+type Writer struct {
+	w io.Writer
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// 라벨 이름과 값을 번갈아 지정
+type Labels []string
+
+func (l Labels) String() string {
+	if len(l) == 0 {
+		return ""
+	}
+	pairs := []string{}
+	for i := 0; i+1 < len(l); i += 2 {
+		pairs = append(pairs, l[i]+"=\""+escapeLabel(l[i+1])+"\"")
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatValue(v float64) string {
+	if math.IsInf(v, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// 지표 설명과 종류 기록: 같은 지표의 값을 기록하기 전에 한 번 호출
+func (mw *Writer) Header(name, typ, help string) {
+	fmt.Fprintf(mw.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+func (mw *Writer) Value(name string, labels Labels, v float64) {
+	fmt.Fprintf(mw.w, "%s%s %s\n", name, labels, formatValue(v))
+}
+
+func (mw *Writer) Counter(name, help string, v int64) {
+	mw.Header(name, "counter", help)
+	mw.Value(name, nil, float64(v))
+}
+
+func (mw *Writer) Gauge(name, help string, v float64) {
+	mw.Header(name, "gauge", help)
+	mw.Value(name, nil, v)
+}
+
+func (mw *Writer) Histogram(name, help string, h *Histogram) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	mw.Header(name, "histogram", help)
+	var cumulative uint64
+	for i, b := range h.buckets {
+		cumulative += h.counts[i]
+		mw.Value(name+"_bucket", Labels{"le", formatValue(b)}, float64(cumulative))
+	}
+	mw.Value(name+"_bucket", Labels{"le", "+Inf"}, float64(h.count))
+	mw.Value(name+"_sum", nil, h.sum)
+	mw.Value(name+"_count", nil, float64(h.count))
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(mw *Writer)
+		want  string
+	}{
+		{
+			name:  "counter",
+			write: func(mw *Writer) { mw.Counter("requests_total", "Requests handled.", 42) },
+			want: "# HELP requests_total Requests handled.\n" +
+				"# TYPE requests_total counter\n" +
+				"requests_total 42\n",
+		},
+		{
+			name:  "gauge",
+			write: func(mw *Writer) { mw.Gauge("ratio", "Fill ratio.", 0.25) },
+			want: "# HELP ratio Fill ratio.\n" +
+				"# TYPE ratio gauge\n" +
+				"ratio 0.25\n",
+		},
+		{
+			name: "labeled values",
+			write: func(mw *Writer) {
+				mw.Header("waiting", "gauge", "Waiting clients.")
+				mw.Value("waiting", Labels{"queue", "duel"}, 3)
+				mw.Value("waiting", Labels{"queue", "team", "region", "kr"}, 0)
+			},
+			want: "# HELP waiting Waiting clients.\n" +
+				"# TYPE waiting gauge\n" +
+				"waiting{queue=\"duel\"} 3\n" +
+				"waiting{queue=\"team\",region=\"kr\"} 0\n",
+		},
+		{
+			name: "label escaping",
+			write: func(mw *Writer) {
+				mw.Value("m", Labels{"name", "a\\b\"c\nd"}, 1)
+			},
+			want: "m{name=\"a\\\\b\\\"c\\nd\"} 1\n",
+		},
+		{
+			name: "large and small values",
+			write: func(mw *Writer) {
+				mw.Value("big", nil, 1e21)
+				mw.Value("small", nil, 0.0001)
+			},
+			want: "big 1e+21\n" +
+				"small 0.0001\n",
+		},
+		{
+			name: "histogram",
+			write: func(mw *Writer) {
+				h := NewHistogram([]float64{1, 0.1, 0.5}) // 정렬되지 않은 구간도 오름차순으로 기록
+				for _, v := range []float64{0.05, 0.1, 0.3, 0.7, 2, 5} {
+					h.Observe(v)
+				}
+				mw.Histogram("tick_seconds", "Tick duration.", h)
+			},
+			// 구간 상한과 같은 값은 해당 구간에 포함되고, 구간 값은 누적
+			want: "# HELP tick_seconds Tick duration.\n" +
+				"# TYPE tick_seconds histogram\n" +
+				"tick_seconds_bucket{le=\"0.1\"} 2\n" +
+				"tick_seconds_bucket{le=\"0.5\"} 3\n" +
+				"tick_seconds_bucket{le=\"1\"} 4\n" +
+				"tick_seconds_bucket{le=\"+Inf\"} 6\n" +
+				"tick_seconds_sum 8.15\n" +
+				"tick_seconds_count 6\n",
+		},
+		{
+			name: "empty histogram",
+			write: func(mw *Writer) {
+				mw.Histogram("empty_seconds", "Nothing observed.", NewHistogram([]float64{1}))
+			},
+			want: "# HELP empty_seconds Nothing observed.\n" +
+				"# TYPE empty_seconds histogram\n" +
+				"empty_seconds_bucket{le=\"1\"} 0\n" +
+				"empty_seconds_bucket{le=\"+Inf\"} 0\n" +
+				"empty_seconds_sum 0\n" +
+				"empty_seconds_count 0\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sb strings.Builder
+			tt.write(NewWriter(&sb))
+			if got := sb.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := &Counter{}
+	c.Inc()
+	c.Add(41)
+	if c.Value() != 42 {
+		t.Errorf("Value = %d, want 42", c.Value())
+	}
+}
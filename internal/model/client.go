@@ -1,40 +1,274 @@
 package model
 
-import "github.com/gorilla/websocket"
+import (
+	"log/slog"
+	"space_arena/internal/metrics"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
 
 const (
 	CLIENT_STATUS_CONNECTED    = "connected"
 	CLIENT_STATUS_DISCONNECTED = "disconnected"
 )
 
+const (
+	CLIENT_SKIN_DEFAULT = -1 // 플레이어 인덱스에 따른 기본 색상
+)
+
+const (
+	CLIENT_SEND_BUFFER_SIZE = 1000 // 클라이언트별 전송 대기 메시지 최대 수
+	CLIENT_SEND_RECOVERED   = 0.5  // 전송 대기 메시지가 버퍼 크기의 이 비율 미만으로 줄면 버퍼 초과 구간 종료
+)
+
+// 전송 버퍼가 가득 찬 느린 클라이언트 처리 방식: 버려도 되는 메시지(Droppable)가 없으면 연결 해제
+const (
+	CLIENT_SEND_POLICY_DROP_OLDEST = "drop_oldest" // 버려도 되는 메시지를 오래된 것부터 버림
+	CLIENT_SEND_POLICY_COALESCE    = "coalesce"    // 같은 오브젝트의 상태 이벤트는 마지막 것만 남기고, 그래도 가득 차면 버려도 되는 메시지를 오래된 것부터 버림
+	CLIENT_SEND_POLICY_DISCONNECT  = "disconnect"  // 연결 해제
+)
+
+// 모든 클라이언트의 전송 버퍼 초과 통계
+var (
+	SendDropped     = &metrics.Counter{} // 버려진 메시지 수
+	SendCoalesced   = &metrics.Counter{} // 합쳐져서 버려진 메시지 수
+	SendDisconnects = &metrics.Counter{} // 연결 해제된 클라이언트 수
+)
+
+// 모든 클라이언트의 웹소켓 왕복 시간(sec)
+var RTTSeconds = metrics.NewHistogram([]float64{0.01, 0.025, 0.05, 0.1, 0.15, 0.25, 0.5, 1})
+
+func ValidSendPolicy(policy string) bool {
+	switch policy {
+	case CLIENT_SEND_POLICY_DROP_OLDEST, CLIENT_SEND_POLICY_COALESCE, CLIENT_SEND_POLICY_DISCONNECT:
+		return true
+	}
+	return false
+}
+
 type Client struct {
-	Id      string
-	GameId  string
-	Status  string
-	Conn    *websocket.Conn
-	msgChan chan Msg
+	Id            string
+	Status        string
+	Authenticated bool   // 계정으로 로그인한 클라이언트 여부
+	Name          string // 표시 이름
+	Skin          int    // 우주선 스킨
+	PartyId       string // 참가 중인 파티 아이디
+	Addr          string // 접속 IP 주소
+	SendPolicy    string // 전송 버퍼가 가득 찬 경우 처리 방식
+	Conn          *websocket.Conn
+	msgChan       chan Msg
+	msgMu         sync.Mutex   // AddMsg와 CloseChan 동기화
+	overflowed    bool         // 버퍼 초과 구간인지 여부: 전송 버퍼가 가득 차면 설정되고, 버퍼가 줄어들면 해제
+	closed        bool         // 전송 채널을 닫았는지 여부
+	coalesced     bool         // 이번 버퍼 초과 구간에서 이미 상태 이벤트를 합쳤는지 여부
+	exhausted     bool         // 이번 버퍼 초과 구간에서 버릴 수 있는 메시지가 없어 공간을 만들지 못했는지 여부
+	rtt           atomic.Int64 // 마지막으로 측정한 웹소켓 왕복 시간(ns)
+	gameId        atomic.Value // 참가 중인 게임 아이디(string): 매칭 고루틴에서 기록하고 여러 고루틴에서 읽음
 }
 
 func CreateClient(id string, conn *websocket.Conn) *Client {
 	return &Client{
-		Id:      id,
-		Status:  CLIENT_STATUS_CONNECTED,
-		msgChan: make(chan Msg, 1000),
-		Conn:    conn,
+		Id:         id,
+		Status:     CLIENT_STATUS_CONNECTED,
+		Skin:       CLIENT_SKIN_DEFAULT,
+		SendPolicy: CLIENT_SEND_POLICY_COALESCE,
+		msgChan:    make(chan Msg, CLIENT_SEND_BUFFER_SIZE),
+		Conn:       conn,
 	}
 }
 
+// 웹소켓 ping/pong으로 측정한 왕복 시간, 아직 측정하지 않았으면 0
+func (c *Client) RTT() time.Duration {
+	return time.Duration(c.rtt.Load())
+}
+
+func (c *Client) SetRTT(rtt time.Duration) {
+	c.rtt.Store(int64(rtt))
+	RTTSeconds.Observe(rtt.Seconds())
+}
+
+// 참가 중인 게임 아이디, 게임에 참가하지 않았으면 빈 문자열
+func (c *Client) GameId() string {
+	id, _ := c.gameId.Load().(string)
+	return id
+}
+
+func (c *Client) SetGameId(id string) {
+	c.gameId.Store(id)
+}
+
 func (c *Client) GetMsgChan() chan Msg {
 	return c.msgChan
 }
 
+// 전송 대기 중인 메시지 수와 전송 버퍼 크기
+func (c *Client) SendBuffer() (int, int) {
+	return len(c.msgChan), cap(c.msgChan)
+}
+
+// 전송 버퍼에 메시지 추가: 버퍼가 가득 차도 대기하지 않고 SendPolicy에 따라 처리
 func (c *Client) AddMsg(msg Msg) {
-	if c.Status != CLIENT_STATUS_DISCONNECTED {
+	c.msgMu.Lock()
+	defer c.msgMu.Unlock()
+	if c.Status == CLIENT_STATUS_DISCONNECTED {
+		return
+	}
+	select {
+	case c.msgChan <- msg:
+		if c.overflowed && len(c.msgChan) < int(float64(cap(c.msgChan))*CLIENT_SEND_RECOVERED) {
+			c.overflowed, c.coalesced, c.exhausted = false, false, false
+			slog.Info("client send buffer recovered", "client_id", c.Id, "game_id", c.GameId())
+		}
+		return
+	default:
+	}
+
+	if !c.overflowed {
+		c.overflowed = true
+		slog.Warn("client send buffer full", "client_id", c.Id, "game_id", c.GameId(), "policy", c.SendPolicy)
+	}
+	if c.SendPolicy == CLIENT_SEND_POLICY_DISCONNECT {
+		c.disconnect("send buffer full")
+		return
+	}
+	// 버퍼 전체를 다시 채우는 비용이 크므로 합치기와 버리기는 버퍼 초과 구간마다 필요할 때만 수행
+	if c.SendPolicy == CLIENT_SEND_POLICY_COALESCE && !c.coalesced {
+		c.coalesced = true
+		c.coalesce()
+		if c.trySend(msg) {
+			return
+		}
+	}
+	if !c.exhausted {
+		c.dropOldest()
+		if c.trySend(msg) {
+			return
+		}
+		c.exhausted = true
+	}
+
+	// 버릴 수 있는 메시지가 없는 경우: 버려도 되는 메시지는 버리고, 게임 진행에 필요한 메시지는 연결 해제
+	if Droppable(msg) {
+		SendDropped.Inc()
+		return
+	}
+	c.disconnect("send buffer full of essential messages")
+}
+
+func (c *Client) trySend(msg Msg) bool {
+	select {
+	case c.msgChan <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// 전송 버퍼가 가득 찬 클라이언트 연결 해제: c.msgMu를 잠근 상태에서 호출
+// 연결이 끊기면 웹소켓 수신 고루틴에서 클라이언트 정리
+func (c *Client) disconnect(reason string) {
+	SendDisconnects.Inc()
+	c.Status = CLIENT_STATUS_DISCONNECTED
+	slog.Warn("slow client disconnected", "client_id", c.Id, "game_id", c.GameId(), "reason", reason)
+	if c.Conn != nil {
+		c.Conn.Close()
+	}
+}
+
+// 버려도 되는 메시지를 오래된 것부터 버려 전송 버퍼를 CLIENT_SEND_RECOVERED 비율까지 비움: c.msgMu를 잠근 상태에서 호출
+// 게임 시작, 플레이어 생성, 게임 종료처럼 한 번만 전송되는 메시지는 버리지 않음
+func (c *Client) dropOldest() {
+	msgs := c.drain()
+	excess := len(msgs) - int(float64(cap(c.msgChan))*CLIENT_SEND_RECOVERED)
+	for _, msg := range msgs {
+		if excess > 0 && Droppable(msg) {
+			excess--
+			SendDropped.Inc()
+			continue
+		}
 		c.msgChan <- msg
 	}
 }
 
+// 전송 버퍼의 메시지를 모두 꺼내서 반환: c.msgMu를 잠근 상태에서 호출
+func (c *Client) drain() []Msg {
+	msgs := []Msg{}
+	for len(msgs) < cap(c.msgChan) {
+		select {
+		case msg := <-c.msgChan:
+			msgs = append(msgs, msg)
+			continue
+		default:
+		}
+		break
+	}
+	return msgs
+}
+
+// 같은 오브젝트의 상태 이벤트 중 마지막 것만 남기도록 전송 버퍼 정리: c.msgMu를 잠근 상태에서 호출
+func (c *Client) coalesce() {
+	msgs := c.drain()
+
+	// 뒤에서부터 확인하여 같은 키의 이전 이벤트를 제외
+	seen := map[string]bool{}
+	keep := make([]bool, len(msgs))
+	for i := len(msgs) - 1; i >= 0; i-- {
+		key, ok := coalesceKey(msgs[i])
+		if ok && seen[key] {
+			SendCoalesced.Inc()
+			continue
+		}
+		seen[key] = true
+		keep[i] = true
+	}
+	for i, msg := range msgs {
+		if keep[i] {
+			c.msgChan <- msg
+		}
+	}
+}
+
+// 마지막 상태만 전송해도 되는 이벤트의 키
+func coalesceKey(msg Msg) (string, bool) {
+	if msg.Type != MSG_TYPE_INGAME {
+		return "", false
+	}
+	ev := msg.Event
+	switch ev.Type {
+	case EVENT_TYPE_PLAYER_MOVE, EVENT_TYPE_OBSTACLE_MOVE, EVENT_TYPE_GAME_ZONE:
+		return ev.Type + ":" + ev.OwnerId + ":" + ev.Data.Id, true
+	}
+	return "", false
+}
+
+// 전송 버퍼가 가득 찬 경우 버려도 되는 메시지: 이후 메시지로 갱신되는 상태 이벤트와 표시용 이벤트
+// 그 외 메시지는 버리면 클라이언트 상태가 서버와 달라지므로 버리지 않음
+func Droppable(msg Msg) bool {
+	if _, ok := coalesceKey(msg); ok {
+		return true
+	}
+	switch msg.Type {
+	case MSG_TYPE_QUEUES:
+		return true
+	case MSG_TYPE_INGAME:
+		switch msg.Event.Type {
+		case EVENT_TYPE_PLAYER_LATENCY, EVENT_TYPE_PLAYER_EMOTE, EVENT_TYPE_PLAYER_PING,
+			EVENT_TYPE_KILL_FEED, EVENT_TYPE_PLAYER_COLLIDE:
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Client) CloseChan() {
+	c.msgMu.Lock()
+	defer c.msgMu.Unlock()
 	c.Status = CLIENT_STATUS_DISCONNECTED
-	close(c.msgChan)
+	if !c.closed {
+		c.closed = true
+		close(c.msgChan)
+	}
 }
@@ -0,0 +1,123 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ingameMsg(eventType, ownerId string) Msg {
+	return MakeMsg("a", MSG_TYPE_INGAME, Event{Type: eventType, OwnerId: ownerId})
+}
+
+// 서로 다른 오브젝트의 이동 이벤트: 합칠 수 없지만 버릴 수 있음
+func moveMsg(i int) Msg {
+	return ingameMsg(EVENT_TYPE_PLAYER_MOVE, fmt.Sprint("p", i))
+}
+
+func bufferedTypes(c *Client) map[string]int {
+	types := map[string]int{}
+	for _, msg := range c.drain() {
+		if msg.Type == MSG_TYPE_INGAME {
+			types[msg.Event.Type]++
+		} else {
+			types[msg.Type]++
+		}
+	}
+	return types
+}
+
+// 버퍼가 가득 차도 한 번만 전송되는 메시지는 버리지 않음
+func TestAddMsgKeepsEssentialMessages(t *testing.T) {
+	for _, policy := range []string{CLIENT_SEND_POLICY_COALESCE, CLIENT_SEND_POLICY_DROP_OLDEST} {
+		t.Run(policy, func(t *testing.T) {
+			c := CreateClient("a", nil)
+			c.SendPolicy = policy
+			c.AddMsg(MakeMsg("a", MSG_TYPE_START, Event{}))
+			c.AddMsg(ingameMsg(EVENT_TYPE_GAME_INIT, "a"))
+			c.AddMsg(ingameMsg(EVENT_TYPE_PLAYER_CREATE, "b"))
+			for i := 0; i < CLIENT_SEND_BUFFER_SIZE*3; i++ {
+				c.AddMsg(moveMsg(i))
+			}
+			c.AddMsg(ingameMsg(EVENT_TYPE_PLAYER_DEAD, "b"))
+			c.AddMsg(MakeMsg("a", MSG_TYPE_END, Event{}))
+
+			if c.Status == CLIENT_STATUS_DISCONNECTED {
+				t.Fatal("client disconnected while droppable messages were buffered")
+			}
+			types := bufferedTypes(c)
+			for _, want := range []string{MSG_TYPE_START, EVENT_TYPE_GAME_INIT, EVENT_TYPE_PLAYER_CREATE, EVENT_TYPE_PLAYER_DEAD, MSG_TYPE_END} {
+				if types[want] != 1 {
+					t.Errorf("%s buffered %d times, want 1", want, types[want])
+				}
+			}
+		})
+	}
+}
+
+// 버릴 수 있는 메시지가 없으면 버려도 되는 메시지는 버리고, 필요한 메시지는 연결 해제
+func TestAddMsgBufferFullOfEssentialMessages(t *testing.T) {
+	c := CreateClient("a", nil)
+	for i := 0; i < CLIENT_SEND_BUFFER_SIZE; i++ {
+		c.AddMsg(ingameMsg(EVENT_TYPE_PROJECTILE_CREATE, "b"))
+	}
+
+	c.AddMsg(ingameMsg(EVENT_TYPE_PLAYER_LATENCY, "a"))
+	if c.Status == CLIENT_STATUS_DISCONNECTED {
+		t.Fatal("client disconnected by droppable message")
+	}
+	c.AddMsg(MakeMsg("a", MSG_TYPE_END, Event{}))
+	if c.Status != CLIENT_STATUS_DISCONNECTED {
+		t.Fatal("essential message dropped without disconnecting client")
+	}
+}
+
+// 버퍼가 절반 아래로 줄어들면 버퍼 초과 구간이 끝나고, 다음 초과 구간에서 다시 상태 이벤트를 합침
+func TestAddMsgOverflowRecovers(t *testing.T) {
+	c := CreateClient("a", nil)
+	for i := 0; i < CLIENT_SEND_BUFFER_SIZE+1; i++ {
+		c.AddMsg(moveMsg(i))
+	}
+	if !c.overflowed || !c.coalesced {
+		t.Fatalf("overflowed = %v, coalesced = %v after overflow, want true", c.overflowed, c.coalesced)
+	}
+
+	// 클라이언트가 버퍼를 비운 후 다음 메시지에서 초과 구간 종료
+	c.drain()
+	c.AddMsg(moveMsg(0))
+	if c.overflowed || c.coalesced || c.exhausted {
+		t.Errorf("overflowed = %v, coalesced = %v, exhausted = %v after recovery, want false", c.overflowed, c.coalesced, c.exhausted)
+	}
+
+	// 같은 오브젝트의 이동 이벤트는 다음 초과 구간에서 다시 합쳐짐
+	c.drain()
+	for i := 0; i < CLIENT_SEND_BUFFER_SIZE+1; i++ {
+		c.AddMsg(moveMsg(0))
+	}
+	if n, _ := c.SendBuffer(); n != 2 {
+		t.Errorf("buffered = %d after coalescing, want 2", n)
+	}
+}
+
+func TestDroppable(t *testing.T) {
+	tests := []struct {
+		msg  Msg
+		want bool
+	}{
+		{msg: ingameMsg(EVENT_TYPE_PLAYER_MOVE, "a"), want: true},
+		{msg: ingameMsg(EVENT_TYPE_OBSTACLE_MOVE, "a"), want: true},
+		{msg: ingameMsg(EVENT_TYPE_PLAYER_LATENCY, "a"), want: true},
+		{msg: MakeMsg("a", MSG_TYPE_QUEUES, Event{}), want: true},
+		{msg: ingameMsg(EVENT_TYPE_GAME_INIT, "a"), want: false},
+		{msg: ingameMsg(EVENT_TYPE_PLAYER_CREATE, "a"), want: false},
+		{msg: ingameMsg(EVENT_TYPE_PLAYER_DEAD, "a"), want: false},
+		{msg: ingameMsg(EVENT_TYPE_PROJECTILE_CREATE, "a"), want: false},
+		{msg: MakeMsg("a", MSG_TYPE_START, Event{}), want: false},
+		{msg: MakeMsg("a", MSG_TYPE_END, Event{}), want: false},
+	}
+	for _, tt := range tests {
+		name := tt.msg.Type + ":" + tt.msg.Event.Type
+		if got := Droppable(tt.msg); got != tt.want {
+			t.Errorf("Droppable(%s) = %v, want %v", name, got, tt.want)
+		}
+	}
+}
@@ -2,15 +2,29 @@ package model
 
 const (
 	EVENT_TYPE_GAME_INIT             = "game_init"
+	EVENT_TYPE_GAME_ZONE             = "game_zone"
 	EVENT_TYPE_GAME_OVER             = "game_over"
 	EVENT_TYPE_GAME_VICTORY          = "game_victory"
 	EVENT_TYPE_PLAYER_DISCONNECT     = "player_disconnect"
 	EVENT_TYPE_PLAYER_CREATE         = "player_create"
 	EVENT_TYPE_PLAYER_DEAD           = "player_dead"
+	EVENT_TYPE_KILL_FEED             = "kill_feed"
 	EVENT_TYPE_PLAYER_MOVE           = "player_move"
 	EVENT_TYPE_PLAYER_FIRE           = "player_fire"
+	EVENT_TYPE_PLAYER_COLLIDE        = "player_collide"
+	EVENT_TYPE_PLAYER_BOOST          = "player_boost"
+	EVENT_TYPE_PLAYER_BOOST_READY    = "player_boost_ready"
+	EVENT_TYPE_PLAYER_BOOST_COOLDOWN = "player_boost_cooldown"
+	EVENT_TYPE_PLAYER_EMOTE          = "player_emote"
+	EVENT_TYPE_PLAYER_PING           = "player_ping"
+	EVENT_TYPE_PLAYER_LATENCY        = "player_latency"
 	EVENT_TYPE_PROJECTILE_CREATE     = "projectile_create"
 	EVENT_TYPE_PROJECTILE_EXTINCTION = "projectile_extinction"
+	EVENT_TYPE_PROJECTILE_DEFLECT    = "projectile_deflect"
+	EVENT_TYPE_OBSTACLE_CREATE       = "obstacle_create"
+	EVENT_TYPE_OBSTACLE_MOVE         = "obstacle_move"
+	EVENT_TYPE_OBSTACLE_HIT          = "obstacle_hit"
+	EVENT_TYPE_OBSTACLE_DESTROY      = "obstacle_destroy"
 )
 
 type Event struct {
@@ -28,6 +42,22 @@ type EventData struct {
 	DirX        int     `json:"dir_x"`
 	DirY        int     `json:"dir_y"`
 	DirR        int     `json:"dir_r"`
+	VX          float64 `json:"vx"`
+	VY          float64 `json:"vy"`
+	VR          float64 `json:"vr"`
 	MoveSpeed   float64 `json:"move_speed"`
 	RotateSpeed float64 `json:"rotate_speed"`
+	R           float64 `json:"r"`
+	Hp          int     `json:"hp"`
+	FlightModel string  `json:"flight_model,omitempty"`
+	Cooldown    float64 `json:"cooldown"`
+	Name        string  `json:"name,omitempty"`
+	Skin        *int    `json:"skin,omitempty"` // profile 메시지에서 nil이면 스킨을 변경하지 않음
+	KillerId    string  `json:"killer_id,omitempty"`
+	KillerName  string  `json:"killer_name,omitempty"`
+	Weapon      int     `json:"weapon"`
+	Kills       int     `json:"kills"`
+	Team        int     `json:"team"`
+	Latency     float64 `json:"latency,omitempty"` // 웹소켓 왕복 시간(ms)
+	Wait        float64 `json:"wait,omitempty"`    // 다음 축소 시작까지 남은 시간(sec), 축소 중이거나 예정된 축소가 없으면 0
 }
@@ -1,22 +1,78 @@
 package model
 
 const (
-	MSG_TYPE_HELLO  = "hello"  // 첫 연결
-	MSG_TYPE_CLOSE  = "close"  // 연결 해제
-	MSG_TYPE_READY  = "ready"  // 게임 준비
-	MSG_TYPE_CANCEL = "cancel" // 게임 준비 취소
-	MSG_TYPE_START  = "start"  // 게임 시작
-	MSG_TYPE_INGAME = "ingame" // 인게임 메시지
-	MSG_TYPE_END    = "end"    // 게임 종료
-	MSG_TYPE_ERROR  = "error"  // 에러
+	MSG_TYPE_HELLO        = "hello"        // 첫 연결
+	MSG_TYPE_CLOSE        = "close"        // 연결 해제
+	MSG_TYPE_READY        = "ready"        // 게임 준비
+	MSG_TYPE_CANCEL       = "cancel"       // 게임 준비 취소
+	MSG_TYPE_START        = "start"        // 게임 시작
+	MSG_TYPE_INGAME       = "ingame"       // 인게임 메시지
+	MSG_TYPE_END          = "end"          // 게임 종료
+	MSG_TYPE_ERROR        = "error"        // 에러
+	MSG_TYPE_PROFILE      = "profile"      // 표시 이름 및 우주선 스킨 설정
+	MSG_TYPE_QUEUES       = "queues"       // 매칭 대기열 목록 및 인원
+	MSG_TYPE_PARTY_INVITE = "party_invite" // 파티 초대
+	MSG_TYPE_PARTY_ACCEPT = "party_accept" // 파티 초대 수락
+	MSG_TYPE_PARTY_LEAVE  = "party_leave"  // 파티 탈퇴
+	MSG_TYPE_PARTY        = "party"        // 파티 상태
+	MSG_TYPE_CHAT         = "chat"         // 채팅
+	MSG_TYPE_MUTE         = "mute"         // 채팅 음소거
+	MSG_TYPE_UNMUTE       = "unmute"       // 채팅 음소거 해제
+	MSG_TYPE_BLOCK        = "block"        // 차단: 채팅 및 파티 초대를 받지 않음
+	MSG_TYPE_UNBLOCK      = "unblock"      // 차단 해제
 )
 
 type Msg struct {
-	Type     string `json:"type"`
-	ClientId string `json:"client_id"`
-	Event    Event  `json:"event"`
+	Type     string      `json:"type"`
+	ClientId string      `json:"client_id"`
+	Event    Event       `json:"event"`
+	Error    string      `json:"error,omitempty"`
+	Queue    string      `json:"queue,omitempty"`  // ready: 참가할 대기열 이름, 비어 있으면 기본 대기열
+	Queues   []QueueInfo `json:"queues,omitempty"` // queues: 대기열 목록
+	Party    *PartyInfo  `json:"party,omitempty"`  // party, party_invite: 파티 상태, party 메시지에서 nil이면 파티 없음
+	Chat     *ChatMsg    `json:"chat,omitempty"`   // chat: 채팅 메시지
+}
+
+type QueueInfo struct {
+	Name      string   `json:"name"`
+	Mode      string   `json:"mode"`
+	PlayerNum int      `json:"player_num"`
+	TeamNum   int      `json:"team_num,omitempty"`
+	Maps      []string `json:"maps"`
+	Waiting   int      `json:"waiting"` // 매칭 대기 중인 인원
+	Playing   int      `json:"playing"` // 이 대기열에서 시작된 게임을 진행 중인 인원
 }
 
 func MakeMsg(clientId, msgType string, ev Event) Msg {
 	return Msg{ClientId: clientId, Type: msgType, Event: ev}
 }
+
+func MakeErrorMsg(clientId, err string) Msg {
+	return Msg{ClientId: clientId, Type: MSG_TYPE_ERROR, Error: err}
+}
+
+type PartyInfo struct {
+	Id       string        `json:"id"`
+	LeaderId string        `json:"leader_id"`
+	Members  []PartyMember `json:"members"`
+}
+
+type PartyMember struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+const (
+	CHAT_CHANNEL_LOBBY     = "lobby"     // 게임에 참여하지 않은 모든 클라이언트
+	CHAT_CHANNEL_ROOM      = "room"      // 파티원
+	CHAT_CHANNEL_GAME      = "game"      // 같은 게임의 모든 플레이어
+	CHAT_CHANNEL_TEAM      = "team"      // 같은 게임의 같은 팀 플레이어
+	CHAT_CHANNEL_SPECTATOR = "spectator" // 같은 게임의 탈락한 플레이어
+)
+
+type ChatMsg struct {
+	Channel    string `json:"channel"`
+	SenderId   string `json:"sender_id,omitempty"`
+	SenderName string `json:"sender_name,omitempty"`
+	Text       string `json:"text"`
+}
@@ -0,0 +1,194 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	ADMIN_REQUEST_MAX_SIZE = 1024
+)
+
+type adminClient struct {
+	Id            string  `json:"id"`
+	Name          string  `json:"name"`
+	Addr          string  `json:"addr"`
+	Authenticated bool    `json:"authenticated"`
+	GameId        string  `json:"game_id,omitempty"`
+	PartyId       string  `json:"party_id,omitempty"`
+	Queue         string  `json:"queue,omitempty"`
+	RTT           float64 `json:"rtt"`         // 웹소켓 왕복 시간(ms)
+	SendBuffer    int     `json:"send_buffer"` // 전송 대기 중인 메시지 수
+}
+
+type adminGame struct {
+	Id      string            `json:"id"`
+	Mode    string            `json:"mode"`
+	Map     string            `json:"map"`
+	Players []adminGamePlayer `json:"players"`
+}
+
+type adminGamePlayer struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Team  int    `json:"team"`
+	Alive bool   `json:"alive"`
+}
+
+type adminBanRequest struct {
+	AccountId string `json:"account_id"`
+	IP        string `json:"ip"`
+	Duration  string `json:"duration"` // time.ParseDuration 형식
+	Reason    string `json:"reason"`
+}
+
+// 차단 목록 저장소 설정: BAN_STORE_PATH를 memory로 지정하면 메모리에만 저장
+func (s *Server) setupBans() {
+	path := utils.Getevn("BAN_STORE_PATH", "./data/bans.json")
+	if path == "memory" {
+		path = ""
+	}
+	bans, err := NewBanList(path)
+	if err != nil {
+		utils.Fatal("ban store error", "err", err)
+	}
+	s.bans = bans
+	s.adminToken = utils.Getevn("ADMIN_TOKEN", "")
+	if s.adminToken == "" {
+		slog.Info("ADMIN_TOKEN is not set, admin api disabled")
+	}
+}
+
+// 관리자 토큰(Authorization: Bearer <ADMIN_TOKEN>)을 검증하는 핸들러, ADMIN_TOKEN이 없으면 관리자 API 비활성화
+func (s *Server) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.adminToken == "" {
+			writeError(w, http.StatusNotFound, "admin api disabled")
+			return
+		}
+		token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) AdminClientsController(w http.ResponseWriter, r *http.Request) {
+	clients := []adminClient{}
+	s.clients.Range(func(id string, c *model.Client) bool {
+		ac := adminClient{
+			Id: c.Id, Name: c.Name, Addr: c.Addr, Authenticated: c.Authenticated,
+			GameId: c.GameId(), PartyId: c.PartyId,
+			RTT: float64(c.RTT()) / float64(time.Millisecond),
+		}
+		ac.SendBuffer, _ = c.SendBuffer()
+		if q := s.queueOf(c); q != nil {
+			ac.Queue = q.Name
+		}
+		clients = append(clients, ac)
+		return true
+	})
+	writeJSON(w, http.StatusOK, clients)
+}
+
+func (s *Server) AdminGamesController(w http.ResponseWriter, r *http.Request) {
+	games := []adminGame{}
+	for _, g := range s.games.Values() {
+		ag := adminGame{Id: g.Id(), Mode: g.Mode(), Map: g.MapName(), Players: []adminGamePlayer{}}
+		for _, p := range g.Players() {
+			ag.Players = append(ag.Players, adminGamePlayer{Id: p.Id, Name: p.Name, Team: p.Team, Alive: g.IsAlive(p.Id)})
+		}
+		games = append(games, ag)
+	}
+	writeJSON(w, http.StatusOK, games)
+}
+
+func (s *Server) AdminKickController(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.clients.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "client not found")
+		return
+	}
+	s.kick(c, "kicked by admin")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// 게임 강제 종료: 게임 결과는 저장하지 않음
+func (s *Server) AdminTerminateGameController(w http.ResponseWriter, r *http.Request) {
+	g, ok := s.games.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "game not found")
+		return
+	}
+	g.Stop()
+	slog.Info("game terminated by admin", "game_id", g.Id())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) AdminBansController(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.bans.List())
+}
+
+// 계정 또는 IP 차단: 차단 대상이 접속 중이면 연결 해제
+func (s *Server) AdminBanController(w http.ResponseWriter, r *http.Request) {
+	var req adminBanRequest
+	r.Body = http.MaxBytesReader(w, r.Body, ADMIN_REQUEST_MAX_SIZE)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	var key string
+	switch {
+	case req.AccountId != "" && req.IP == "":
+		key = accountBanKey(req.AccountId)
+	case req.IP != "" && req.AccountId == "":
+		key = ipBanKey(req.IP)
+	default:
+		writeError(w, http.StatusBadRequest, "either account_id or ip is required")
+		return
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		writeError(w, http.StatusBadRequest, "invalid duration")
+		return
+	}
+
+	ban, err := s.bans.Add(key, req.Reason, duration)
+	if err != nil {
+		slog.Error("BanList.Add error", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	slog.Info("banned by admin", "ban_key", ban.Key, "expires_at", ban.ExpiresAt, "reason", ban.Reason)
+
+	s.clients.Range(func(id string, c *model.Client) bool {
+		if (c.Authenticated && accountBanKey(c.Id) == key) || ipBanKey(c.Addr) == key {
+			s.kick(c, "banned")
+		}
+		return true
+	})
+	writeJSON(w, http.StatusCreated, ban)
+}
+
+func (s *Server) AdminUnbanController(w http.ResponseWriter, r *http.Request) {
+	ok, err := s.bans.Remove(r.PathValue("key"))
+	if err != nil {
+		slog.Error("BanList.Remove error", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "ban not found")
+		return
+	}
+	slog.Info("unbanned by admin", "ban_key", r.PathValue("key"))
+	w.WriteHeader(http.StatusNoContent)
+}
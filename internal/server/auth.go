@@ -0,0 +1,259 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"space_arena/internal/account"
+	"space_arena/internal/utils"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AUTH_TOKEN_TTL        = time.Hour * 24
+	AUTH_REQUEST_MAX_SIZE = 1024
+	AUTH_LIMITER_PRUNE    = time.Minute // IP별 요청 빈도 기록 정리 주기
+)
+
+type authRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type authResponse struct {
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+	Token string `json:"token"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// 계정 API 요청 보호: 비밀번호 해시 계산 비용이 크므로 IP별로 요청 빈도를 제한하고 로그인 실패를 위반으로 기록
+type authLimiter struct {
+	mu           sync.Mutex
+	limit        msgRateLimit // IP별 요청 빈도 제한
+	buckets      map[string]*authBucket
+	lastPrune    time.Time
+	failureLimit int // 로그인 실패가 이 횟수 이상 누적되면 위반 기록이 초기화될 때까지 로그인 거부, 0이면 거부하지 않음
+}
+
+type authBucket struct {
+	tb   *utils.TokenBucket
+	last time.Time
+}
+
+// IP의 요청 빈도 제한을 통과하면 true 반환
+func (al *authLimiter) allow(ip string) bool {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	now := time.Now()
+	if now.Sub(al.lastPrune) > AUTH_LIMITER_PRUNE {
+		// 토큰이 모두 다시 채워질 만큼 요청이 없던 IP는 새로 만들어도 같으므로 제거
+		refill := time.Duration(float64(al.limit.burst) / al.limit.rate * float64(time.Second))
+		for key, b := range al.buckets {
+			if now.Sub(b.last) > refill {
+				delete(al.buckets, key)
+			}
+		}
+		al.lastPrune = now
+	}
+	b, ok := al.buckets[ip]
+	if !ok {
+		b = &authBucket{tb: utils.NewTokenBucket(al.limit.rate, al.limit.burst)}
+		al.buckets[ip] = b
+	}
+	b.last = now
+	return b.tb.Allow()
+}
+
+// 계정 저장소 및 세션 토큰 서명 키 설정
+func (s *Server) setupAuth() {
+	path := utils.Getevn("ACCOUNT_STORE_PATH", "./data/accounts.json")
+	if path == "memory" {
+		s.accounts = account.NewMemoryStore()
+	} else {
+		store, err := account.NewFileStore(path)
+		if err != nil {
+			utils.Fatal("account store error", "err", err)
+		}
+		s.accounts = store
+	}
+
+	secret := []byte(utils.Getevn("AUTH_SECRET", ""))
+	if len(secret) == 0 {
+		// 서버를 재시작하면 기존 토큰은 무효화됨
+		slog.Warn("AUTH_SECRET is not set, use random secret")
+		secret = make([]byte, 32)
+		rand.Read(secret)
+	}
+	s.tokens = account.NewTokenSigner(secret, AUTH_TOKEN_TTL)
+	s.authRequired = utils.Getevn("AUTH_REQUIRED", "false") == "true"
+
+	rate, err := strconv.ParseFloat(utils.Getevn("AUTH_RATE_LIMIT", "0.2"), 64)
+	if err != nil || rate <= 0 {
+		utils.Fatal("invalid AUTH_RATE_LIMIT", "err", err)
+	}
+	burst, err := strconv.Atoi(utils.Getevn("AUTH_BURST_LIMIT", "5"))
+	if err != nil || burst <= 0 {
+		utils.Fatal("invalid AUTH_BURST_LIMIT", "err", err)
+	}
+	failureLimit, err := strconv.Atoi(utils.Getevn("AUTH_LOGIN_FAILURE_LIMIT", "10"))
+	if err != nil || failureLimit < 0 {
+		utils.Fatal("invalid AUTH_LOGIN_FAILURE_LIMIT", "err", err)
+	}
+	s.authLimiter = &authLimiter{
+		limit:        msgRateLimit{rate: rate, burst: burst},
+		buckets:      map[string]*authBucket{},
+		failureLimit: failureLimit,
+	}
+}
+
+// 계정 API 요청 제한: 차단된 IP와 요청 빈도 제한을 넘긴 IP의 요청 거부
+func (s *Server) authLimit(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ip := s.remoteIP(r)
+		if _, ok := s.bans.Check(ipBanKey(ip)); ok {
+			writeError(w, http.StatusForbidden, "banned")
+			return
+		}
+		if !s.authLimiter.allow(ip) {
+			slog.Warn("auth request throttled", "addr", ip, "path", r.URL.Path)
+			writeError(w, http.StatusTooManyRequests, "too many requests")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// 로그인 실패 위반 기록 키
+func loginViolationKey(ip string) string { return "login:" + ip }
+
+// 로그인 실패를 IP 단위 위반으로 기록: IP 차단을 사용하는 경우 임계값을 넘으면 IP 차단
+func (s *Server) loginFailure(ip, name string) {
+	vt := s.violations
+	key := loginViolationKey(ip)
+	count := vt.add(key)
+	slog.Warn("violation", "addr", ip, "name", name, "violation_key", key, "reason", "login failure", "count", count)
+
+	if vt.ipBan && vt.banThreshold > 0 && count >= vt.banThreshold {
+		ban, err := s.bans.Add(ipBanKey(ip), "violation: login failure", vt.banDuration)
+		if err != nil {
+			slog.Error("BanList.Add error", "addr", ip, "err", err)
+		}
+		slog.Warn("ip banned", "addr", ip, "ban_key", ban.Key, "expires_at", ban.ExpiresAt)
+	}
+}
+
+func (s *Server) RegisterController(w http.ResponseWriter, r *http.Request) {
+	req, ok := readAuthRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := account.ValidateName(req.Name); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := account.ValidatePassword(req.Password); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	hash, err := account.HashPassword(req.Password)
+	if err != nil {
+		slog.Error("account.HashPassword error", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	a := &account.Account{
+		Id:           utils.RandomCapAlphaNumeric(10),
+		Name:         req.Name,
+		PasswordHash: hash,
+		CreatedAt:    time.Now(),
+	}
+	if err := s.accounts.Create(a); err != nil {
+		if errors.Is(err, account.ErrAccountExists) {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		slog.Error("accounts.Create error", "err", err)
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	slog.Info("account registered", "client_id", a.Id, "name", a.Name)
+
+	writeJSON(w, http.StatusCreated, authResponse{Id: a.Id, Name: a.Name, Token: s.tokens.Sign(a.Id)})
+}
+
+func (s *Server) LoginController(w http.ResponseWriter, r *http.Request) {
+	req, ok := readAuthRequest(w, r)
+	if !ok {
+		return
+	}
+	ip := s.remoteIP(r)
+	if limit := s.authLimiter.failureLimit; limit > 0 && s.violations.count(loginViolationKey(ip)) >= limit {
+		writeError(w, http.StatusTooManyRequests, "too many login failures")
+		return
+	}
+	a, err := s.accounts.GetByName(req.Name)
+	if err != nil || !account.VerifyPassword(req.Password, a.PasswordHash) {
+		s.loginFailure(ip, req.Name)
+		writeError(w, http.StatusUnauthorized, "invalid name or password")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, authResponse{Id: a.Id, Name: a.Name, Token: s.tokens.Sign(a.Id)})
+}
+
+// 웹소켓 연결 요청의 세션 토큰을 검증하고 클라이언트 아이디를 반환
+// 토큰이 없는 경우 인증이 필수가 아니면 임시 아이디를 발급
+func (s *Server) authenticate(r *http.Request) (string, *account.Account, error) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		token, _ = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	if token == "" {
+		if s.authRequired {
+			return "", nil, account.ErrInvalidToken
+		}
+		return utils.RandomCapAlphaNumeric(10), nil, nil
+	}
+
+	accountId, err := s.tokens.Verify(token)
+	if err != nil {
+		return "", nil, err
+	}
+	a, err := s.accounts.GetById(accountId)
+	if err != nil {
+		return "", nil, err
+	}
+	return a.Id, a, nil
+}
+
+func readAuthRequest(w http.ResponseWriter, r *http.Request) (authRequest, bool) {
+	var req authRequest
+	r.Body = http.MaxBytesReader(w, r.Body, AUTH_REQUEST_MAX_SIZE)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return req, false
+	}
+	return req, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("json.Encode error", "err", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
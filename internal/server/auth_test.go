@@ -0,0 +1,127 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"space_arena/internal/account"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuthenticate(t *testing.T) {
+	accounts := account.NewMemoryStore()
+	accounts.Create(&account.Account{Id: "ACCOUNT01", Name: "alice"})
+	tokens := account.NewTokenSigner([]byte("secret"), time.Hour)
+	valid := tokens.Sign("ACCOUNT01")
+	expired := account.NewTokenSigner([]byte("secret"), -time.Hour).Sign("ACCOUNT01")
+	unknown := tokens.Sign("DELETED01")
+
+	tests := []struct {
+		name         string
+		authRequired bool
+		query        string
+		header       string
+		wantId       string // 비어 있으면 임시 아이디
+		wantAccount  bool
+		wantErr      error
+	}{
+		{name: "token in query", query: valid, wantId: "ACCOUNT01", wantAccount: true},
+		{name: "token in header", header: "Bearer " + valid, wantId: "ACCOUNT01", wantAccount: true},
+		{name: "guest", wantAccount: false},
+		{name: "guest when auth required", authRequired: true, wantErr: account.ErrInvalidToken},
+		{name: "invalid token", query: "garbage", wantErr: account.ErrInvalidToken},
+		{name: "expired token", header: "Bearer " + expired, wantErr: account.ErrExpiredToken},
+		{name: "unknown account", query: unknown, wantErr: account.ErrAccountNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Server{accounts: accounts, tokens: tokens, authRequired: tt.authRequired}
+			target := "/ws"
+			if tt.query != "" {
+				target += "?token=" + tt.query
+			}
+			r := httptest.NewRequest("GET", target, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			id, acc, err := s.authenticate(r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (acc != nil) != tt.wantAccount {
+				t.Errorf("account = %v, want account %v", acc, tt.wantAccount)
+			}
+			if tt.wantId != "" && id != tt.wantId {
+				t.Errorf("id = %q, want %q", id, tt.wantId)
+			}
+			if tt.wantId == "" && len(id) != 10 {
+				t.Errorf("guest id = %q, want random 10 character id", id)
+			}
+		})
+	}
+}
+
+func newAuthTestServer(t *testing.T, limit msgRateLimit, failureLimit int) *Server {
+	t.Helper()
+	bans, _ := NewBanList("")
+	return &Server{
+		accounts:    account.NewMemoryStore(),
+		tokens:      account.NewTokenSigner([]byte("secret"), time.Hour),
+		violations:  &ViolationTracker{records: map[string]*violationRecord{}},
+		bans:        bans,
+		authLimiter: &authLimiter{limit: limit, buckets: map[string]*authBucket{}, failureLimit: failureLimit},
+	}
+}
+
+func postLogin(s *Server, addr, body string) int {
+	r := httptest.NewRequest("POST", "/api/login", strings.NewReader(body))
+	r.RemoteAddr = addr
+	w := httptest.NewRecorder()
+	s.authLimit(s.LoginController)(w, r)
+	return w.Code
+}
+
+// 연속 요청 허용량을 넘으면 비밀번호 확인 전에 거부하고, 다른 IP는 영향을 받지 않음
+func TestAuthRateLimit(t *testing.T) {
+	s := newAuthTestServer(t, msgRateLimit{rate: 0.001, burst: 2}, 0)
+	body := `{"name": "nobody", "password": "password1"}`
+
+	for i, want := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		if got := postLogin(s, "10.0.0.1:1000", body); got != want {
+			t.Fatalf("request %d: status = %d, want %d", i, got, want)
+		}
+	}
+	if got := postLogin(s, "10.0.0.2:1000", body); got != http.StatusUnauthorized {
+		t.Errorf("other ip: status = %d, want %d", got, http.StatusUnauthorized)
+	}
+}
+
+// 로그인 실패가 누적되면 올바른 비밀번호로도 로그인할 수 없음
+func TestLoginFailureLimit(t *testing.T) {
+	s := newAuthTestServer(t, msgRateLimit{rate: 1000, burst: 1000}, 3)
+	hash, _ := account.HashPassword("password1")
+	s.accounts.Create(&account.Account{Id: "ACCOUNT01", Name: "alice", PasswordHash: hash})
+	good := `{"name": "alice", "password": "password1"}`
+	bad := `{"name": "alice", "password": "password2"}`
+
+	if got := postLogin(s, "10.0.0.1:1000", good); got != http.StatusOK {
+		t.Fatalf("login: status = %d, want %d", got, http.StatusOK)
+	}
+	for i := 0; i < 3; i++ {
+		if got := postLogin(s, "10.0.0.1:1000", bad); got != http.StatusUnauthorized {
+			t.Fatalf("failure %d: status = %d, want %d", i, got, http.StatusUnauthorized)
+		}
+	}
+	if got := postLogin(s, "10.0.0.1:1000", good); got != http.StatusTooManyRequests {
+		t.Errorf("after failures: status = %d, want %d", got, http.StatusTooManyRequests)
+	}
+	if got := postLogin(s, "10.0.0.2:1000", good); got != http.StatusOK {
+		t.Errorf("other ip: status = %d, want %d", got, http.StatusOK)
+	}
+}
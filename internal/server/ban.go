@@ -0,0 +1,190 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"sort"
+	"space_arena/internal/utils"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 차단 대상 키: 계정 차단은 "account:<계정 아이디>", IP 차단은 "ip:<IP 주소>"
+func accountBanKey(accountId string) string { return "account:" + accountId }
+func ipBanKey(ip string) string             { return "ip:" + ip }
+
+// 신뢰하는 프록시 주소 설정: TRUSTED_PROXIES에 쉼표로 구분한 IP 또는 CIDR 목록 지정
+func (s *Server) setupTrustedProxies() {
+	for _, v := range strings.Split(utils.Getevn("TRUSTED_PROXIES", ""), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(v)
+			if addrErr != nil {
+				utils.Fatal("invalid TRUSTED_PROXIES", "value", v, "err", err)
+			}
+			prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+		}
+		s.trustedProxies = append(s.trustedProxies, prefix.Masked())
+	}
+}
+
+func (s *Server) isTrustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range s.trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// 요청의 접속 IP 주소: 신뢰하는 프록시를 거친 요청이면 X-Forwarded-For에서 신뢰하는 프록시가 아닌 가장 오른쪽 주소 사용
+func (s *Server) remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	if !s.isTrustedProxy(ip) {
+		return ip
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if _, err := netip.ParseAddr(hop); err != nil {
+			// 잘못된 주소가 있으면 그 앞의 주소는 신뢰할 수 없음
+			break
+		}
+		ip = hop
+		if !s.isTrustedProxy(hop) {
+			break
+		}
+	}
+	return ip
+}
+
+type Ban struct {
+	Key       string    `json:"key"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// 접속 차단 목록: path를 지정하면 차단 목록이 변경될 때마다 JSON 파일 전체를 다시 기록
+type BanList struct {
+	mu   sync.Mutex
+	bans map[string]Ban
+	path string
+}
+
+// 파일에서 차단 목록을 불러옴, path가 비어 있으면 메모리에만 저장
+func NewBanList(path string) (*BanList, error) {
+	bl := &BanList{bans: map[string]Ban{}, path: path}
+	if path == "" {
+		return bl, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return bl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("NewBanList: %w", err)
+	}
+	bans := []Ban{}
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("NewBanList: %s: %w", path, err)
+	}
+	now := time.Now()
+	for _, ban := range bans {
+		if now.Before(ban.ExpiresAt) {
+			bl.bans[ban.Key] = ban
+		}
+	}
+	return bl, nil
+}
+
+// 차단 추가: 이미 차단된 키이면 차단 정보를 덮어씀
+func (bl *BanList) Add(key, reason string, duration time.Duration) (Ban, error) {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	ban := Ban{Key: key, Reason: reason, ExpiresAt: time.Now().Add(duration)}
+	bl.bans[key] = ban
+	return ban, bl.save()
+}
+
+// 차단 해제: 차단되어 있지 않으면 false 반환
+func (bl *BanList) Remove(key string) (bool, error) {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	if _, ok := bl.bans[key]; !ok {
+		return false, nil
+	}
+	delete(bl.bans, key)
+	return true, bl.save()
+}
+
+// 만료되지 않은 차단 목록(만료 시각순)
+func (bl *BanList) List() []Ban {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	now := time.Now()
+	bans := []Ban{}
+	for _, ban := range bl.bans {
+		if now.Before(ban.ExpiresAt) {
+			bans = append(bans, ban)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].ExpiresAt.Before(bans[j].ExpiresAt) })
+	return bans
+}
+
+// 키 중 하나라도 차단되어 있으면 해당 차단 정보 반환, 만료된 차단은 삭제
+func (bl *BanList) Check(keys ...string) (Ban, bool) {
+	bl.mu.Lock()
+	defer bl.mu.Unlock()
+	now := time.Now()
+	for _, key := range keys {
+		ban, ok := bl.bans[key]
+		if !ok {
+			continue
+		}
+		if now.After(ban.ExpiresAt) {
+			delete(bl.bans, key)
+			continue
+		}
+		return ban, true
+	}
+	return Ban{}, false
+}
+
+// 만료된 차단은 파일에서 제외: bl.mu를 잠근 상태에서 호출
+func (bl *BanList) save() error {
+	if bl.path == "" {
+		return nil
+	}
+	now := time.Now()
+	bans := []Ban{}
+	for _, ban := range bl.bans {
+		if now.Before(ban.ExpiresAt) {
+			bans = append(bans, ban)
+		}
+	}
+	data, err := json.MarshalIndent(bans, "", "  ")
+	if err != nil {
+		return fmt.Errorf("BanList.save: %w", err)
+	}
+	return utils.WriteFileAtomic(bl.path, data)
+}
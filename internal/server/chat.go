@@ -0,0 +1,179 @@
+package server
+
+import (
+	"fmt"
+	"space_arena/internal/game"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	CHAT_MAX_LENGTH = 200 // 채팅 메시지 최대 길이(문자 수)
+	CHAT_RATE       = 1   // 초당 채팅 메시지 수
+	CHAT_BURST      = 5   // 연속으로 보낼 수 있는 채팅 메시지 수
+)
+
+// 클라이언트별 채팅 상태: msgHandler 고루틴에서만 접근
+type chatState struct {
+	limiter *utils.TokenBucket
+	muted   map[string]bool // 채팅을 받지 않는 클라이언트 아이디
+	blocked map[string]bool // 채팅 및 파티 초대를 받지 않는 클라이언트 아이디
+}
+
+func (s *Server) chatState(id string) *chatState {
+	cs, ok := s.chatStates.Get(id)
+	if !ok {
+		cs = &chatState{
+			limiter: utils.NewTokenBucket(CHAT_RATE, CHAT_BURST),
+			muted:   map[string]bool{},
+			blocked: map[string]bool{},
+		}
+		s.chatStates.Set(id, cs)
+	}
+	return cs
+}
+
+// 수신자가 발신자의 채팅을 받지 않도록 설정했는지 여부
+func (s *Server) isChatIgnored(receiverId, senderId string) bool {
+	cs, ok := s.chatStates.Get(receiverId)
+	return ok && (cs.muted[senderId] || cs.blocked[senderId])
+}
+
+func (s *Server) isBlocked(receiverId, senderId string) bool {
+	cs, ok := s.chatStates.Get(receiverId)
+	return ok && cs.blocked[senderId]
+}
+
+// 채팅 메시지 처리: 채널에 따라 수신자를 정하고, 음소거하거나 차단한 수신자를 제외하고 전송
+func (s *Server) chat(c *model.Client, chat *model.ChatMsg) {
+	if chat == nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "invalid chat message"))
+		return
+	}
+	text, ok := sanitizeChat(chat.Text)
+	if !ok {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "invalid chat message"))
+		return
+	}
+	if !s.chatState(c.Id).limiter.Allow() {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "chat rate limited"))
+		return
+	}
+
+	receivers, err := s.chatReceivers(c, chat.Channel)
+	if err != nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, err.Error()))
+		return
+	}
+
+	msg := &model.ChatMsg{Channel: chat.Channel, SenderId: c.Id, SenderName: c.Name, Text: text}
+	for _, r := range receivers {
+		if r != c && s.isChatIgnored(r.Id, c.Id) {
+			continue
+		}
+		r.AddMsg(model.Msg{ClientId: r.Id, Type: model.MSG_TYPE_CHAT, Chat: msg})
+	}
+}
+
+// 채널별 수신자 목록, 발신할 수 없는 채널이면 에러 반환
+func (s *Server) chatReceivers(c *model.Client, channel string) ([]*model.Client, error) {
+	receivers := []*model.Client{}
+	switch channel {
+	case model.CHAT_CHANNEL_LOBBY:
+		if c.GameId() != "" {
+			return nil, fmt.Errorf("not in lobby")
+		}
+		s.clients.Range(func(id string, rc *model.Client) bool {
+			if rc.GameId() == "" {
+				receivers = append(receivers, rc)
+			}
+			return true
+		})
+
+	case model.CHAT_CHANNEL_ROOM:
+		s.partyMu.Lock()
+		p := s.partyOf(c)
+		if p != nil {
+			receivers = append(receivers, p.Members...)
+		}
+		s.partyMu.Unlock()
+		if p == nil {
+			return nil, fmt.Errorf("not in party")
+		}
+
+	case model.CHAT_CHANNEL_GAME, model.CHAT_CHANNEL_TEAM, model.CHAT_CHANNEL_SPECTATOR:
+		g, ok := s.games.Get(c.GameId())
+		if !ok {
+			return nil, fmt.Errorf("not in game")
+		}
+		sender, ok := g.Player(c.Id)
+		if !ok {
+			return nil, fmt.Errorf("not in game")
+		}
+		alive := g.IsAlive(c.Id)
+		if channel == model.CHAT_CHANNEL_SPECTATOR && alive {
+			return nil, fmt.Errorf("spectator channel is only for eliminated players")
+		}
+		if channel != model.CHAT_CHANNEL_SPECTATOR && !alive && s.spectatorChat {
+			return nil, fmt.Errorf("eliminated players can only use spectator channel")
+		}
+		for _, p := range g.Players() {
+			if chatVisible(g, channel, sender, p) {
+				receivers = append(receivers, p.Client)
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown chat channel")
+	}
+	return receivers, nil
+}
+
+// 인게임 채널의 메시지를 플레이어가 받을 수 있는지 여부
+func chatVisible(g *game.Game, channel string, sender, p *game.Player) bool {
+	switch channel {
+	case model.CHAT_CHANNEL_TEAM:
+		return p.Team == sender.Team
+	case model.CHAT_CHANNEL_SPECTATOR:
+		return !g.IsAlive(p.Id)
+	}
+	return true
+}
+
+// 공백과 제어 문자를 정리하고 길이를 검증
+func sanitizeChat(text string) (string, bool) {
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, text)
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > CHAT_MAX_LENGTH {
+		return "", false
+	}
+	return text, true
+}
+
+// 음소거 및 차단 목록 변경 메시지 처리: data.id는 대상 클라이언트 아이디
+func (s *Server) setChatFilter(c *model.Client, msgType, targetId string) {
+	if targetId == "" || targetId == c.Id {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "invalid target"))
+		return
+	}
+	cs := s.chatState(c.Id)
+	switch msgType {
+	case model.MSG_TYPE_MUTE:
+		cs.muted[targetId] = true
+	case model.MSG_TYPE_UNMUTE:
+		delete(cs.muted, targetId)
+	case model.MSG_TYPE_BLOCK:
+		cs.blocked[targetId] = true
+	case model.MSG_TYPE_UNBLOCK:
+		delete(cs.blocked, targetId)
+	}
+	c.AddMsg(model.MakeMsg(c.Id, msgType, model.Event{OwnerId: c.Id, Data: model.EventData{Id: targetId}}))
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"log/slog"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	MSG_FLOOD_WINDOW = time.Second * 5 // 과도한 메시지 전송을 판단하는 측정 구간
+	MSG_FLOOD_FACTOR = 4               // 측정 구간 동안 빈도 제한 허용량의 이 배수를 넘겨 보내면 위반으로 기록
+	MSG_THROTTLE_LOG = 50              // 빈도 제한으로 버려진 메시지가 이 개수만큼 쌓일 때마다 기록
+)
+
+// 메시지 빈도 제한 설정: 초당 rate개, 연속 burst개
+type msgRateLimit struct {
+	rate  float64
+	burst int
+}
+
+// 측정 구간 동안 과도한 전송으로 판단하는 메시지 수: 빈도 제한으로 허용되는 최대 개수의 MSG_FLOOD_FACTOR배
+func (l msgRateLimit) floodThreshold() int {
+	return int(MSG_FLOOD_FACTOR * (l.rate*MSG_FLOOD_WINDOW.Seconds() + float64(l.burst)))
+}
+
+// 메시지 종류별 빈도 제한
+var msgRateLimits = map[string]msgRateLimit{
+	model.MSG_TYPE_INGAME: {rate: 90, burst: 180},
+	model.MSG_TYPE_CHAT:   {rate: 5, burst: 10},
+}
+
+// 위 목록에 없는 메시지 종류의 빈도 제한
+var msgRateLimitDefault = msgRateLimit{rate: 5, burst: 10}
+
+// 수신 메시지 보호 설정
+type floodConfig struct {
+	readLimit int64        // 웹소켓 메시지 최대 크기(byte)
+	total     msgRateLimit // 클라이언트당 전체 메시지 빈도 제한
+}
+
+// 수신 메시지 통계
+type floodStats struct {
+	throttled atomic.Int64 // 빈도 제한으로 버려진 메시지 수
+	dropped   atomic.Int64 // 수신 채널이 가득 차서 버려진 메시지 수
+}
+
+func (s *Server) setupFlood() {
+	readLimit, err := strconv.ParseInt(utils.Getevn("WS_MAX_MESSAGE_SIZE", "4096"), 10, 64)
+	if err != nil || readLimit <= 0 {
+		utils.Fatal("invalid WS_MAX_MESSAGE_SIZE", "err", err)
+	}
+	rate, err := strconv.ParseFloat(utils.Getevn("MSG_RATE_LIMIT", "100"), 64)
+	if err != nil || rate <= 0 {
+		utils.Fatal("invalid MSG_RATE_LIMIT", "err", err)
+	}
+	burst, err := strconv.Atoi(utils.Getevn("MSG_BURST_LIMIT", "200"))
+	if err != nil || burst <= 0 {
+		utils.Fatal("invalid MSG_BURST_LIMIT", "err", err)
+	}
+	s.flood = floodConfig{readLimit: readLimit, total: msgRateLimit{rate: rate, burst: burst}}
+}
+
+// 빈도 제한 버킷과 측정 구간 동안 받은 메시지 수
+type msgBucket struct {
+	tb    *utils.TokenBucket
+	limit msgRateLimit
+	count int
+}
+
+func newMsgBucket(limit msgRateLimit) *msgBucket {
+	return &msgBucket{tb: utils.NewTokenBucket(limit.rate, limit.burst), limit: limit}
+}
+
+// 클라이언트별 수신 메시지 빈도 제한: 웹소켓 수신 고루틴에서만 접근
+// 빈도 제한을 넘긴 메시지는 버리기만 하고, 허용량의 몇 배를 계속 보내는 경우에만 위반으로 기록
+type msgLimiter struct {
+	total       *msgBucket
+	types       map[string]*msgBucket // msgRateLimits의 메시지 종류별 버킷
+	other       *msgBucket            // 그 외 메시지 종류가 함께 사용하는 버킷
+	windowStart time.Time
+	throttled   int // 빈도 제한으로 버려진 메시지 수
+}
+
+func (s *Server) newMsgLimiter(now time.Time) *msgLimiter {
+	ml := &msgLimiter{
+		total:       newMsgBucket(s.flood.total),
+		types:       map[string]*msgBucket{},
+		other:       newMsgBucket(msgRateLimitDefault),
+		windowStart: now,
+	}
+	for msgType, limit := range msgRateLimits {
+		ml.types[msgType] = newMsgBucket(limit)
+	}
+	return ml
+}
+
+func (ml *msgLimiter) bucket(msgType string) *msgBucket {
+	if b, ok := ml.types[msgType]; ok {
+		return b
+	}
+	return ml.other
+}
+
+// 전체 및 메시지 종류별 빈도 제한을 모두 통과하면 true 반환
+// 전체 빈도 제한을 먼저 확인하여 전체 제한으로 버려지는 메시지가 종류별 허용량을 소모하지 않음
+func (ml *msgLimiter) allow(msgType string, now time.Time) bool {
+	b := ml.bucket(msgType)
+	ml.total.count++
+	b.count++
+	return ml.total.tb.AllowAt(now) && b.tb.AllowAt(now)
+}
+
+// 측정 구간이 끝나면 허용량의 MSG_FLOOD_FACTOR배를 넘겨 보냈는지 확인하고 구간 초기화
+// 넘긴 경우 해당 메시지 종류("total"이면 전체)와 true 반환
+func (ml *msgLimiter) flood(now time.Time) (string, bool) {
+	if now.Sub(ml.windowStart) < MSG_FLOOD_WINDOW {
+		return "", false
+	}
+	flooded, ok := "", false
+	if ml.total.count > ml.total.limit.floodThreshold() {
+		flooded, ok = "total", true
+	}
+	buckets := map[string]*msgBucket{"other": ml.other}
+	for msgType, b := range ml.types {
+		buckets[msgType] = b
+	}
+	for msgType, b := range buckets {
+		if !ok && b.count > b.limit.floodThreshold() {
+			flooded, ok = msgType, true
+		}
+		b.count = 0
+	}
+	ml.total.count = 0
+	ml.windowStart = now
+	return flooded, ok
+}
+
+// 빈도 제한을 넘긴 메시지 처리: 처음 제한될 때와 일정 개수마다 기록
+func (s *Server) throttle(id string, ml *msgLimiter, msgType string) {
+	ml.throttled++
+	s.floodStats.throttled.Add(1)
+	if ml.throttled == 1 || ml.throttled%MSG_THROTTLE_LOG == 0 {
+		slog.Warn("client throttled", "client_id", id, "msg_type", msgType, "throttled", ml.throttled)
+	}
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"space_arena/internal/model"
+	"testing"
+	"time"
+)
+
+func newTestMsgLimiter(now time.Time) *msgLimiter {
+	s := &Server{flood: floodConfig{total: msgRateLimit{rate: 100, burst: 200}}}
+	return s.newMsgLimiter(now)
+}
+
+// 전송률(Hz)로 메시지를 보내며 빈도 제한을 통과한 수와 위반 여부 반환
+func runMsgStream(ml *msgLimiter, start time.Time, hz float64, duration time.Duration, msgType string) (int, bool) {
+	allowed, flooded := 0, false
+	frames := int(hz * duration.Seconds())
+	for i := 0; i < frames; i++ {
+		now := start.Add(time.Duration(float64(i) / hz * float64(time.Second)))
+		if ml.allow(msgType, now) {
+			allowed++
+		}
+		if _, ok := ml.flood(now); ok {
+			flooded = true
+		}
+	}
+	return allowed, flooded
+}
+
+// 레이저 발사 키를 누르고 있는 동안 매 프레임 이벤트를 보내는 144Hz 클라이언트는 제한되어도 위반이 아님
+func TestMsgLimiterHighRefreshFire(t *testing.T) {
+	start := time.Now()
+	ml := newTestMsgLimiter(start)
+	allowed, flooded := runMsgStream(ml, start, 144, time.Second*30, model.MSG_TYPE_INGAME)
+	if flooded {
+		t.Error("144Hz fire stream recorded as message flood")
+	}
+	// 연속 허용량(180)과 초당 90개를 넘는 메시지만 버려짐
+	if want := 180 + 90*30; allowed < want-2 || allowed > want+2 {
+		t.Errorf("allowed = %d, want about %d", allowed, want)
+	}
+}
+
+func TestMsgLimiterFlood(t *testing.T) {
+	tests := []struct {
+		name    string
+		hz      float64
+		msgType string
+		want    bool
+	}{
+		{name: "ingame 60Hz", hz: 60, msgType: model.MSG_TYPE_INGAME, want: false},
+		{name: "ingame 240Hz", hz: 240, msgType: model.MSG_TYPE_INGAME, want: false},
+		{name: "ingame 1000Hz", hz: 1000, msgType: model.MSG_TYPE_INGAME, want: true},
+		{name: "chat 10Hz", hz: 10, msgType: model.MSG_TYPE_CHAT, want: false},
+		{name: "chat 50Hz", hz: 50, msgType: model.MSG_TYPE_CHAT, want: true},
+		{name: "unknown type 50Hz", hz: 50, msgType: "unknown", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			ml := newTestMsgLimiter(start)
+			if _, flooded := runMsgStream(ml, start, tt.hz, time.Second*20, tt.msgType); flooded != tt.want {
+				t.Errorf("flooded = %v, want %v", flooded, tt.want)
+			}
+		})
+	}
+}
+
+// 목록에 없는 메시지 종류는 하나의 버킷을 함께 사용하며 종류마다 버킷을 만들지 않음
+func TestMsgLimiterUnknownTypes(t *testing.T) {
+	now := time.Now()
+	ml := newTestMsgLimiter(now)
+	allowed := 0
+	for i := 0; i < 100; i++ {
+		if ml.allow(string(rune('A'+i%26))+string(rune('a'+i/26)), now) {
+			allowed++
+		}
+	}
+	if allowed != msgRateLimitDefault.burst {
+		t.Errorf("allowed = %d, want %d", allowed, msgRateLimitDefault.burst)
+	}
+	if len(ml.types) != len(msgRateLimits) {
+		t.Errorf("types = %d buckets, want %d", len(ml.types), len(msgRateLimits))
+	}
+}
+
+// 전체 빈도 제한으로 버려진 메시지는 종류별 허용량을 소모하지 않음
+func TestMsgLimiterTotalFirst(t *testing.T) {
+	now := time.Now()
+	ml := newTestMsgLimiter(now)
+	for i := 0; i < 200; i++ {
+		ml.allow(model.MSG_TYPE_INGAME, now)
+	}
+	for i := 0; i < 20; i++ {
+		if ml.allow(model.MSG_TYPE_CHAT, now) {
+			t.Fatal("message allowed after total limit exhausted")
+		}
+	}
+	// 전체 허용량이 다시 채워지면 채팅 연속 허용량은 그대로 남아 있음
+	later := now.Add(time.Second * 2)
+	allowed := 0
+	for i := 0; i < 20; i++ {
+		if ml.allow(model.MSG_TYPE_CHAT, later) {
+			allowed++
+		}
+	}
+	if allowed != msgRateLimits[model.MSG_TYPE_CHAT].burst {
+		t.Errorf("chat allowed = %d, want %d", allowed, msgRateLimits[model.MSG_TYPE_CHAT].burst)
+	}
+}
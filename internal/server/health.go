@@ -0,0 +1,84 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"space_arena/internal/model"
+	"time"
+)
+
+type statusResponse struct {
+	Uptime   float64           `json:"uptime"` // 서버 실행 시간(sec)
+	Draining bool              `json:"draining"`
+	Clients  int               `json:"clients"`
+	Queues   []model.QueueInfo `json:"queues"`
+	Games    []statusGame      `json:"games"`
+}
+
+type statusGame struct {
+	Id      string  `json:"id"`
+	Mode    string  `json:"mode"`
+	Map     string  `json:"map"`
+	Players int     `json:"players"`
+	Alive   int     `json:"alive"`
+	Tick    int64   `json:"tick"`
+	TickLag float64 `json:"tick_lag"` // 마지막 틱이 예정보다 늦게 시작된 시간(ms)
+	Elapsed float64 `json:"elapsed"`  // 게임 진행 시간(sec)
+}
+
+// 서버 프로세스가 요청을 처리할 수 있으면 항상 성공
+func (s *Server) HealthController(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// 새 연결을 받을 수 있는지 여부: 종료 대기 중이면 실패
+func (s *Server) ReadyController(w http.ResponseWriter, r *http.Request) {
+	if s.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "draining"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+func (s *Server) StatusController(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	games := []statusGame{}
+	for _, g := range s.games.Values() {
+		st := g.Status()
+		games = append(games, statusGame{
+			Id: st.Id, Mode: st.Mode, Map: st.MapName, Players: st.Players, Alive: st.Alive,
+			Tick: st.Tick, TickLag: float64(st.TickLag) / float64(time.Millisecond),
+			Elapsed: now.Sub(st.StartedAt).Seconds(),
+		})
+	}
+	writeJSON(w, http.StatusOK, statusResponse{
+		Uptime:   now.Sub(s.startedAt).Seconds(),
+		Draining: s.draining.Load(),
+		Clients:  s.clients.Len(),
+		Queues:   s.queueInfos(),
+		Games:    games,
+	})
+}
+
+// 종료 대기 시작: 새 연결과 게임 준비를 받지 않고, 대기열의 모든 클라이언트의 준비를 취소
+func (s *Server) drain() {
+	if s.draining.Swap(true) {
+		return
+	}
+	slog.Info("server draining", "games", s.games.Len(), "clients", s.clients.Len())
+	s.clients.Range(func(id string, c *model.Client) bool {
+		s.dequeue(c)
+		return true
+	})
+}
+
+// 진행 중인 게임이 모두 끝나거나 timeout이 지날 때까지 대기
+func (s *Server) waitGames(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for s.games.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+	}
+	if n := s.games.Len(); n > 0 {
+		slog.Warn("drain timeout, games still running", "games", n)
+	}
+}
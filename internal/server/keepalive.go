@@ -0,0 +1,79 @@
+package server
+
+import (
+	"log/slog"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// 웹소켓 연결 유지 설정
+type keepaliveConfig struct {
+	pingInterval time.Duration // ping 전송 주기
+	pongTimeout  time.Duration // 이 시간 동안 pong이나 메시지를 받지 못하면 연결 해제
+	writeTimeout time.Duration // 메시지 전송 제한 시간
+}
+
+func (s *Server) setupKeepalive() {
+	parse := func(key, defaultValue string) time.Duration {
+		d, err := time.ParseDuration(utils.Getevn(key, defaultValue))
+		if err != nil || d <= 0 {
+			utils.Fatal("invalid "+key, "err", err)
+		}
+		return d
+	}
+	s.keepalive = keepaliveConfig{
+		pingInterval: parse("WS_PING_INTERVAL", "10s"),
+		pongTimeout:  parse("WS_PONG_TIMEOUT", "30s"),
+		writeTimeout: parse("WS_WRITE_TIMEOUT", "10s"),
+	}
+	if s.keepalive.pongTimeout <= s.keepalive.pingInterval {
+		utils.Fatal("WS_PONG_TIMEOUT must be longer than WS_PING_INTERVAL")
+	}
+}
+
+// 수신 제한 시간을 설정하고, pong을 받으면 제한 시간을 연장하며 ping 전송 시각으로 왕복 시간 측정
+func (s *Server) watchPong(c *model.Client) {
+	c.Conn.SetReadDeadline(time.Now().Add(s.keepalive.pongTimeout))
+	c.Conn.SetPongHandler(func(data string) error {
+		if sentAt, err := strconv.ParseInt(data, 10, 64); err == nil {
+			c.SetRTT(time.Since(time.Unix(0, sentAt)))
+		}
+		return c.Conn.SetReadDeadline(time.Now().Add(s.keepalive.pongTimeout))
+	})
+}
+
+// 전송 버퍼의 메시지를 클라이언트로 전송하고 주기적으로 ping 전송
+// 전송에 실패하면 연결을 끊어 웹소켓 수신 고루틴에서 클라이언트를 정리
+func (s *Server) writeLoop(c *model.Client) {
+	ticker := time.NewTicker(s.keepalive.pingInterval)
+	defer ticker.Stop()
+	msgChan := c.GetMsgChan()
+	for {
+		select {
+		case msg, ok := <-msgChan:
+			if !ok {
+				return
+			}
+			c.Conn.SetWriteDeadline(time.Now().Add(s.keepalive.writeTimeout))
+			if err := c.Conn.WriteJSON(msg); err != nil {
+				slog.Warn("ws WriteJSON error", "client_id", c.Id, "game_id", c.GameId(), "err", err)
+				c.Conn.Close()
+				return
+			}
+			s.msgStats.out.Inc()
+
+		case <-ticker.C:
+			payload := strconv.FormatInt(time.Now().UnixNano(), 10)
+			deadline := time.Now().Add(s.keepalive.writeTimeout)
+			if err := c.Conn.WriteControl(websocket.PingMessage, []byte(payload), deadline); err != nil {
+				slog.Warn("ws ping error", "client_id", c.Id, "game_id", c.GameId(), "err", err)
+				c.Conn.Close()
+				return
+			}
+		}
+	}
+}
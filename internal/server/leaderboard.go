@@ -0,0 +1,81 @@
+package server
+
+import (
+	"net/http"
+	"space_arena/internal/stats"
+)
+
+type leaderboardResponse struct {
+	Season  string                   `json:"season"`
+	Sort    string                   `json:"sort"`
+	Total   int                      `json:"total"`
+	Offset  int                      `json:"offset"`
+	Limit   int                      `json:"limit"`
+	Entries []stats.LeaderboardEntry `json:"entries"`
+}
+
+type leaderboardRankResponse struct {
+	Season string `json:"season"`
+	Sort   string `json:"sort"`
+	stats.LeaderboardEntry
+}
+
+func (s *Server) LeaderboardController(w http.ResponseWriter, r *http.Request) {
+	season, sortBy, ok := readLeaderboardQuery(w, r)
+	if !ok {
+		return
+	}
+	offset, limit, ok := readPage(w, r)
+	if !ok {
+		return
+	}
+	entries, total := s.stats.Leaderboard(season, sortBy, offset, limit)
+	writeJSON(w, http.StatusOK, leaderboardResponse{
+		Season: season, Sort: sortBy, Total: total, Offset: offset, Limit: limit, Entries: entries,
+	})
+}
+
+// 세션 토큰의 계정 플레이어 순위
+func (s *Server) LeaderboardRankController(w http.ResponseWriter, r *http.Request) {
+	season, sortBy, ok := readLeaderboardQuery(w, r)
+	if !ok {
+		return
+	}
+	id, a, err := s.authenticate(r)
+	if err != nil || a == nil {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+	e, ok := s.stats.LeaderboardRank(season, sortBy, id)
+	if !ok {
+		writeError(w, http.StatusNotFound, "player not ranked")
+		return
+	}
+	writeJSON(w, http.StatusOK, leaderboardRankResponse{Season: season, Sort: sortBy, LeaderboardEntry: e})
+}
+
+// 리더보드 요청 파라미터(season, sort) 파싱
+// season: global(기본값), current 또는 YYYY-MM, sort: rating(기본값), wins, kills, survival
+func readLeaderboardQuery(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	season := r.URL.Query().Get("season")
+	switch season {
+	case "":
+		season = stats.SEASON_GLOBAL
+	case "current":
+		season = stats.CurrentSeason()
+	}
+	if !stats.ValidSeason(season) {
+		writeError(w, http.StatusBadRequest, "invalid season")
+		return "", "", false
+	}
+
+	sortBy := r.URL.Query().Get("sort")
+	if sortBy == "" {
+		sortBy = stats.LEADERBOARD_SORT_RATING
+	}
+	if !stats.ValidLeaderboardSort(sortBy) {
+		writeError(w, http.StatusBadRequest, "invalid sort")
+		return "", "", false
+	}
+	return season, sortBy, true
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"space_arena/internal/model"
+	"sync"
+	"time"
+)
+
+const (
+	MATCH_RATING_WINDOW        = 100              // 매칭 허용 레이팅 차이 초기값
+	MATCH_RATING_WINDOW_GROWTH = 25               // 대기 시간에 따른 매칭 허용 레이팅 차이 증가량(per sec)
+	MATCH_MAX_WAIT             = time.Second * 60 // 최대 대기 시간: 초과하면 레이팅과 관계없이 매칭
+	MATCH_INTERVAL             = time.Second      // 대기 시간에 따라 매칭을 다시 시도하는 주기
+	MATCH_FILL_SEARCH_LIMIT    = 10000            // 기준 그룹마다 참가자 조합을 탐색하는 최대 횟수
+)
+
+// 매칭 대기 중인 클라이언트 그룹(개인 또는 파티): 같은 게임, 같은 팀에 배정됨
+type matchTicket struct {
+	clients  []*model.Client
+	rating   float64 // 그룹의 평균 레이팅
+	queuedAt time.Time
+}
+
+// 매칭된 게임 참가자
+type matchGroup struct {
+	clients []*model.Client
+	teams   []int // clients와 같은 순서의 팀 번호, 개인전이면 nil
+}
+
+// 레이팅이 비슷한 클라이언트끼리 묶어주는 매치메이커
+// 대기 시간이 길어질수록 허용 레이팅 차이가 넓어지고, 최대 대기 시간을 넘기면 최소 인원만 모여도 매칭
+type Matchmaker struct {
+	mu         sync.Mutex
+	tickets    []*matchTicket // 대기열 진입 순서
+	playerNum  int            // 게임당 플레이어 수
+	minPlayers int            // 최대 대기 시간 초과 시 게임을 시작할 수 있는 최소 플레이어 수
+	teamNum    int            // 팀전의 팀 수, 개인전이면 0
+}
+
+func NewMatchmaker(playerNum, minPlayers, teamNum int) *Matchmaker {
+	return &Matchmaker{playerNum: playerNum, minPlayers: minPlayers, teamNum: teamNum}
+}
+
+// 그룹 단위로 대기열에 추가: 그룹 중 이미 대기 중인 클라이언트가 있으면 실패
+func (mm *Matchmaker) Enqueue(clients []*model.Client, rating float64) bool {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for _, c := range clients {
+		if mm.indexOf(c) >= 0 {
+			return false
+		}
+	}
+	mm.tickets = append(mm.tickets, &matchTicket{clients: clients, rating: rating, queuedAt: time.Now()})
+	return true
+}
+
+// 클라이언트가 속한 그룹을 대기열에서 삭제하고, 삭제된 그룹의 클라이언트를 반환
+func (mm *Matchmaker) Remove(c *model.Client) []*model.Client {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	i := mm.indexOf(c)
+	if i < 0 {
+		return nil
+	}
+	t := mm.tickets[i]
+	mm.tickets = append(mm.tickets[:i], mm.tickets[i+1:]...)
+	return t.clients
+}
+
+func (mm *Matchmaker) Contains(c *model.Client) bool {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	return mm.indexOf(c) >= 0
+}
+
+// 대기 중인 클라이언트 수
+func (mm *Matchmaker) Len() int {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	n := 0
+	for _, t := range mm.tickets {
+		n += len(t.clients)
+	}
+	return n
+}
+
+func (mm *Matchmaker) indexOf(c *model.Client) int {
+	for i, t := range mm.tickets {
+		for _, tc := range t.clients {
+			if tc == c {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// 대기 시간에 따른 매칭 허용 레이팅 차이
+func (t *matchTicket) window(now time.Time) float64 {
+	return MATCH_RATING_WINDOW + MATCH_RATING_WINDOW_GROWTH*now.Sub(t.queuedAt).Seconds()
+}
+
+// 매칭 가능한 그룹을 모아 게임 참가자를 만들고 대기열에서 제거하여 반환
+func (mm *Matchmaker) Match(now time.Time) []matchGroup {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	groups := []matchGroup{}
+	used := map[*matchTicket]bool{}
+
+	// 가장 오래 기다린 그룹부터 기준으로 삼아 레이팅이 가까운 순서로 모집
+	for _, anchor := range mm.tickets {
+		if used[anchor] {
+			continue
+		}
+		candidates := []*matchTicket{}
+		expired := now.Sub(anchor.queuedAt) >= MATCH_MAX_WAIT
+		window := anchor.window(now)
+		for _, t := range mm.tickets {
+			if used[t] {
+				continue
+			}
+			if t == anchor || expired || math.Abs(t.rating-anchor.rating) <= window {
+				candidates = append(candidates, t)
+			}
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return math.Abs(candidates[i].rating-anchor.rating) < math.Abs(candidates[j].rating-anchor.rating)
+		})
+
+		// 인원이 부족한 경우: 최대 대기 시간을 넘긴 경우에만 최소 인원으로 매칭
+		// 한 그룹만으로는 게임을 시작하지 않음
+		selected, teams, n := mm.fill(candidates)
+		if len(selected) < 2 || (n < mm.playerNum && (!expired || n < mm.minPlayers)) {
+			continue
+		}
+
+		group := matchGroup{}
+		for i, t := range selected {
+			used[t] = true
+			group.clients = append(group.clients, t.clients...)
+			if mm.teamNum > 0 {
+				for range t.clients {
+					group.teams = append(group.teams, teams[i])
+				}
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	// 매칭된 그룹을 대기열에서 제거
+	remain := []*matchTicket{}
+	for _, t := range mm.tickets {
+		if !used[t] {
+			remain = append(remain, t)
+		}
+	}
+	mm.tickets = remain
+	return groups
+}
+
+// 게임 인원을 넘지 않도록 후보 그룹을 선택하고, 선택된 그룹의 팀 번호와 전체 인원을 반환
+// 기준 그룹(첫 번째 후보)은 항상 포함하며, 레이팅이 가까운 후보부터 넣어 보고 게임 인원을 채울 수 없으면 되돌아가 다른 조합을 탐색
+// 팀전인 경우 그룹이 나뉘지 않도록 팀 인원을 넘지 않는 팀에 배정하며, 인원이 적은 팀과 레이팅 합이 낮은 팀부터 시도
+// 인원을 모두 채우지 못하면 가장 많은 인원이 모이고 팀 인원 차이가 가장 작은 조합을 반환
+func (mm *Matchmaker) fill(candidates []*matchTicket) ([]*matchTicket, []int, int) {
+	teamCapacity := mm.playerNum
+	if mm.teamNum > 0 {
+		teamCapacity = mm.playerNum / mm.teamNum
+	}
+	fs := &fillSearch{
+		candidates:   candidates,
+		playerNum:    mm.playerNum,
+		teamCapacity: teamCapacity,
+		teamSize:     make([]int, max(mm.teamNum, 1)),
+		teamRating:   make([]float64, max(mm.teamNum, 1)),
+		remain:       make([]int, len(candidates)+1),
+		bestGap:      math.MaxInt,
+	}
+	for i := len(candidates) - 1; i >= 0; i-- {
+		fs.remain[i] = fs.remain[i+1] + len(candidates[i].clients)
+	}
+	if len(candidates) > 0 {
+		fs.search(0)
+	}
+	return fs.best, fs.bestTeams, fs.bestN
+}
+
+// 매칭 참가자 조합 탐색 상태
+type fillSearch struct {
+	candidates   []*matchTicket
+	playerNum    int
+	teamCapacity int
+	teamSize     []int
+	teamRating   []float64
+	remain       []int // 후보 i번째부터 끝까지의 인원 합
+	selected     []*matchTicket
+	teams        []int
+	n            int
+	steps        int
+	best         []*matchTicket
+	bestTeams    []int
+	bestN        int
+	bestGap      int // 가장 많은 팀과 가장 적은 팀의 인원 차이
+}
+
+// i번째 후보부터 조합을 탐색하고, 게임 인원을 모두 채우면 true 반환
+func (fs *fillSearch) search(i int) bool {
+	fs.steps++
+	if gap := fs.gap(); fs.n > fs.bestN || (fs.n == fs.bestN && gap < fs.bestGap) {
+		fs.best = append([]*matchTicket{}, fs.selected...)
+		fs.bestTeams = append([]int{}, fs.teams...)
+		fs.bestN, fs.bestGap = fs.n, gap
+	}
+	if fs.n == fs.playerNum {
+		return true
+	}
+	// 남은 후보를 모두 넣어도 인원이 늘지 않거나, 탐색 횟수를 넘기면 중단
+	if i == len(fs.candidates) || fs.n+fs.remain[i] <= fs.bestN || fs.steps > MATCH_FILL_SEARCH_LIMIT {
+		return false
+	}
+
+	t := fs.candidates[i]
+	size := len(t.clients)
+	if fs.n+size <= fs.playerNum {
+		for _, team := range fs.teamOrder(size) {
+			fs.teamSize[team] += size
+			fs.teamRating[team] += t.rating * float64(size)
+			fs.selected = append(fs.selected, t)
+			fs.teams = append(fs.teams, team)
+			fs.n += size
+			if fs.search(i + 1) {
+				return true
+			}
+			fs.n -= size
+			fs.teams = fs.teams[:len(fs.teams)-1]
+			fs.selected = fs.selected[:len(fs.selected)-1]
+			fs.teamRating[team] -= t.rating * float64(size)
+			fs.teamSize[team] -= size
+		}
+	}
+	// 기준 그룹은 제외하지 않음
+	if i == 0 {
+		return false
+	}
+	return fs.search(i + 1)
+}
+
+// 그룹을 배정할 수 있는 팀을 인원이 적은 순, 레이팅 합이 낮은 순으로 반환
+// 인원이 같은 팀은 배정 결과가 같으므로 하나만 시도
+func (fs *fillSearch) teamOrder(size int) []int {
+	order := []int{}
+	for i := range fs.teamSize {
+		if fs.teamSize[i]+size <= fs.teamCapacity {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if fs.teamSize[order[a]] != fs.teamSize[order[b]] {
+			return fs.teamSize[order[a]] < fs.teamSize[order[b]]
+		}
+		return fs.teamRating[order[a]] < fs.teamRating[order[b]]
+	})
+	result := []int{}
+	for _, team := range order {
+		if len(result) == 0 || fs.teamSize[result[len(result)-1]] != fs.teamSize[team] {
+			result = append(result, team)
+		}
+	}
+	return result
+}
+
+func (fs *fillSearch) gap() int {
+	return slices.Max(fs.teamSize) - slices.Min(fs.teamSize)
+}
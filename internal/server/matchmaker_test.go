@@ -0,0 +1,201 @@
+package server
+
+import (
+	"space_arena/internal/model"
+	"testing"
+	"time"
+)
+
+func newTestClients(ids ...string) []*model.Client {
+	clients := []*model.Client{}
+	for _, id := range ids {
+		clients = append(clients, model.CreateClient(id, nil))
+	}
+	return clients
+}
+
+// 매칭된 게임의 클라이언트 아이디 목록
+func matchedIds(groups []matchGroup) [][]string {
+	result := [][]string{}
+	for _, g := range groups {
+		ids := []string{}
+		for _, c := range g.clients {
+			ids = append(ids, c.Id)
+		}
+		result = append(result, ids)
+	}
+	return result
+}
+
+// 레이팅 차이 150: 허용 차이는 초기 100에서 초당 25씩 늘어나므로 2초 후부터 매칭
+func TestMatchRatingWindowWidens(t *testing.T) {
+	tests := []struct {
+		name    string
+		wait    time.Duration
+		matched bool
+	}{
+		{name: "just queued", wait: 0, matched: false},
+		{name: "1s", wait: time.Second, matched: false},
+		{name: "2s", wait: time.Second * 2, matched: true},
+		{name: "max wait", wait: MATCH_MAX_WAIT, matched: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mm := NewMatchmaker(2, 2, 0)
+			clients := newTestClients("a", "b")
+			mm.Enqueue(clients[:1], 1500)
+			mm.Enqueue(clients[1:], 1650)
+			now := time.Now()
+			for _, ticket := range mm.tickets {
+				ticket.queuedAt = now.Add(-tt.wait)
+			}
+
+			groups := mm.Match(now)
+			if (len(groups) == 1) != tt.matched {
+				t.Fatalf("matched = %v, want %v", matchedIds(groups), tt.matched)
+			}
+			if tt.matched && mm.Len() != 0 {
+				t.Errorf("%d clients left in queue after match", mm.Len())
+			}
+		})
+	}
+}
+
+// 레이팅이 가까운 그룹끼리 먼저 매칭
+func TestMatchClosestRating(t *testing.T) {
+	mm := NewMatchmaker(2, 2, 0)
+	clients := newTestClients("a", "b", "c", "d")
+	for i, rating := range []float64{1500, 1800, 1520, 1790} {
+		mm.Enqueue(clients[i:i+1], rating)
+	}
+
+	got := matchedIds(mm.Match(time.Now()))
+	if len(got) != 2 || got[0][0] != "a" || got[0][1] != "c" || got[1][0] != "b" || got[1][1] != "d" {
+		t.Errorf("matched = %v, want [[a c] [b d]]", got)
+	}
+}
+
+// 파티는 같은 게임, 같은 팀에 배정되고, 파티 전체가 들어갈 자리가 없으면 나뉘지 않고 대기
+func TestMatchPartyStaysTogether(t *testing.T) {
+	mm := NewMatchmaker(4, 4, 2)
+	clients := newTestClients("a", "p1", "p2", "p3", "b", "c")
+	party := newTestClients("q1", "q2")
+	mm.Enqueue(clients[0:1], 1500)
+	mm.Enqueue(clients[1:4], 1500) // 팀 인원(2)보다 큰 파티
+	mm.Enqueue(party, 1500)
+	mm.Enqueue(clients[4:5], 1500)
+	mm.Enqueue(clients[5:6], 1500)
+
+	groups := mm.Match(time.Now())
+	if len(groups) != 1 {
+		t.Fatalf("matched = %v, want one game", matchedIds(groups))
+	}
+	g := groups[0]
+	teamOf := map[string]int{}
+	for i, c := range g.clients {
+		teamOf[c.Id] = g.teams[i]
+	}
+	if _, ok := teamOf["p1"]; ok {
+		t.Errorf("party larger than team matched: %v", matchedIds(groups))
+	}
+	if _, ok := teamOf["q1"]; !ok {
+		t.Fatalf("party not matched: %v", matchedIds(groups))
+	}
+	if teamOf["q1"] != teamOf["q2"] {
+		t.Errorf("party split across teams: %v", teamOf)
+	}
+	if !mm.Contains(clients[1]) || !mm.Contains(clients[3]) || mm.Len() != 4 {
+		t.Errorf("party of 3 should remain queued together, queue len = %d", mm.Len())
+	}
+}
+
+// 순서대로 배정하면 [1, 2, 1] 다음의 2인 파티가 들어갈 팀이 없지만, 1인 그룹을 다른 팀에 배정하면 3:3으로 채울 수 있음
+func TestMatchTeamPacking(t *testing.T) {
+	tests := []struct {
+		name      string
+		playerNum int
+		teamNum   int
+		sizes     []int
+		wantN     int
+	}{
+		{name: "teams [1 2 1 2] capacity 3", playerNum: 6, teamNum: 2, sizes: []int{1, 2, 1, 2}, wantN: 6},
+		{name: "teams [1 1 2 2] capacity 3", playerNum: 6, teamNum: 2, sizes: []int{1, 1, 2, 2}, wantN: 6},
+		{name: "teams [1 1 1 1 2] capacity 2", playerNum: 4, teamNum: 2, sizes: []int{1, 1, 1, 1, 2}, wantN: 4},
+		{name: "three teams [2 1 1 2 1 2]", playerNum: 9, teamNum: 3, sizes: []int{2, 1, 1, 2, 1, 2}, wantN: 9},
+		{name: "ffa [3 2 2]", playerNum: 4, teamNum: 0, sizes: []int{3, 2, 2}, wantN: 0}, // 기준 그룹(3)과 합쳐 4명을 만들 수 없음
+		{name: "ffa [2 3 2]", playerNum: 4, teamNum: 0, sizes: []int{2, 3, 2}, wantN: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mm := NewMatchmaker(tt.playerNum, tt.playerNum, tt.teamNum)
+			sizeOf := map[string]int{}
+			for i, size := range tt.sizes {
+				ids := []string{}
+				for j := 0; j < size; j++ {
+					id := string(rune('a'+i)) + string(rune('0'+j))
+					ids = append(ids, id)
+					sizeOf[id] = size
+				}
+				mm.Enqueue(newTestClients(ids...), 1500)
+			}
+
+			groups := mm.Match(time.Now())
+			if tt.wantN == 0 {
+				if len(groups) != 0 {
+					t.Fatalf("matched = %v, want no match", matchedIds(groups))
+				}
+				return
+			}
+			if len(groups) != 1 || len(groups[0].clients) != tt.wantN {
+				t.Fatalf("matched = %v, want one game of %d", matchedIds(groups), tt.wantN)
+			}
+			if tt.teamNum == 0 {
+				return
+			}
+			// 모든 팀이 같은 인원이고, 파티는 한 팀에 배정됨
+			g := groups[0]
+			teamSize := make([]int, tt.teamNum)
+			teamOf := map[string]int{}
+			for i, c := range g.clients {
+				teamSize[g.teams[i]]++
+				teamOf[c.Id] = g.teams[i]
+			}
+			for team, size := range teamSize {
+				if size != tt.playerNum/tt.teamNum {
+					t.Errorf("team %d has %d players, want %d: %v", team, size, tt.playerNum/tt.teamNum, teamOf)
+				}
+			}
+			for id, team := range teamOf {
+				if sizeOf[id] > 1 && teamOf[id[:1]+"0"] != team {
+					t.Errorf("party %s split across teams: %v", id[:1], teamOf)
+				}
+			}
+		})
+	}
+}
+
+// 게임 인원을 채울 수 없는 조합이 많아도 탐색 횟수 제한 안에서 가장 많은 인원을 반환
+func TestMatchFillSearchLimit(t *testing.T) {
+	mm := NewMatchmaker(5, 4, 0)
+	for i := 0; i < 200; i++ {
+		id := string(rune('A'+i/26)) + string(rune('a'+i%26))
+		mm.Enqueue(newTestClients(id+"1", id+"2"), 1500)
+	}
+	for _, ticket := range mm.tickets {
+		ticket.queuedAt = time.Now().Add(-MATCH_MAX_WAIT)
+	}
+
+	start := time.Now()
+	groups := mm.Match(time.Now())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Match took %v", elapsed)
+	}
+	if len(groups) != 100 {
+		t.Fatalf("matched %d games, want 100 games of 4", len(groups))
+	}
+	for _, g := range groups {
+		if len(g.clients) != 4 {
+			t.Errorf("game of %d players, want 4", len(g.clients))
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"net/http"
+	"space_arena/internal/game"
+	"space_arena/internal/metrics"
+	"space_arena/internal/model"
+)
+
+// 웹소켓 메시지 통계
+type msgStats struct {
+	in  metrics.Counter // 수신한 메시지 수
+	out metrics.Counter // 전송한 메시지 수
+}
+
+// Prometheus 텍스트 형식의 서버 지표
+func (s *Server) MetricsController(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	mw := metrics.NewWriter(w)
+
+	mw.Gauge("space_arena_clients", "Number of connected clients.", float64(s.clients.Len()))
+	mw.Gauge("space_arena_games", "Number of active games.", float64(s.games.Len()))
+
+	mw.Header("space_arena_queue_waiting", "gauge", "Number of clients waiting in each matchmaking queue.")
+	for _, q := range s.queues {
+		mw.Value("space_arena_queue_waiting", metrics.Labels{"queue", q.Name}, float64(q.matchmaker.Len()))
+	}
+	mw.Header("space_arena_queue_playing", "gauge", "Number of clients playing games from each matchmaking queue.")
+	for _, q := range s.queues {
+		mw.Value("space_arena_queue_playing", metrics.Labels{"queue", q.Name}, float64(q.playing.Load()))
+	}
+
+	mw.Histogram("space_arena_game_tick_duration_seconds", "Time spent processing a game tick.", game.TickDuration)
+	mw.Counter("space_arena_game_tick_overruns_total", "Game ticks that took longer than the tick interval.", game.TickOverruns.Value())
+	mw.Counter("space_arena_game_skipped_steps_total", "Simulation steps dropped because a game fell too far behind.", game.SkippedSteps.Value())
+
+	mw.Counter("space_arena_messages_received_total", "WebSocket messages received from clients.", s.msgStats.in.Value())
+	mw.Counter("space_arena_messages_sent_total", "WebSocket messages sent to clients.", s.msgStats.out.Value())
+	mw.Counter("space_arena_messages_throttled_total", "Client messages dropped by rate limits.", s.floodStats.throttled.Load())
+	mw.Counter("space_arena_messages_dropped_total", "Client messages dropped because a receive queue was full.", s.floodStats.dropped.Load())
+
+	mw.Counter("space_arena_send_dropped_total", "Outbound messages dropped because a client's send buffer was full.", model.SendDropped.Value())
+	mw.Counter("space_arena_send_coalesced_total", "Outbound state events replaced by newer ones for slow clients.", model.SendCoalesced.Value())
+	mw.Counter("space_arena_send_disconnects_total", "Clients disconnected because their send buffer was full.", model.SendDisconnects.Value())
+
+	// 클라이언트별 값은 라벨 수가 접속자 수만큼 늘어나므로 서버 전체로 집계
+	maxRatio, backlogged := 0.0, 0
+	s.clients.Range(func(id string, c *model.Client) bool {
+		n, size := c.SendBuffer()
+		ratio := float64(n) / float64(size)
+		maxRatio = max(maxRatio, ratio)
+		if ratio >= model.CLIENT_SEND_RECOVERED {
+			backlogged++
+		}
+		return true
+	})
+	mw.Gauge("space_arena_send_buffer_max_ratio", "Highest outbound message buffer fill ratio among connected clients.", maxRatio)
+	mw.Gauge("space_arena_send_buffer_backlogged_clients", "Clients whose outbound message buffer is at least half full.", float64(backlogged))
+	mw.Histogram("space_arena_client_rtt_seconds", "WebSocket round-trip time measured by ping/pong.", model.RTTSeconds)
+}
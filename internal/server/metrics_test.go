@@ -0,0 +1,43 @@
+package server
+
+import (
+	"net/http/httptest"
+	"space_arena/internal/game"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strings"
+	"testing"
+)
+
+// 클라이언트별 값은 라벨 없이 서버 전체로 집계됨
+func TestMetricsAggregateClients(t *testing.T) {
+	s := &Server{
+		games:   utils.NewSafeMap[string, *game.Game](),
+		clients: utils.NewSafeMap[string, *model.Client](),
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		s.clients.Set(id, model.CreateClient(id, nil))
+	}
+	c, _ := s.clients.Get("b")
+	for i := 0; i < model.CLIENT_SEND_BUFFER_SIZE*3/4; i++ {
+		c.AddMsg(model.Msg{})
+	}
+
+	w := httptest.NewRecorder()
+	s.MetricsController(w, httptest.NewRequest("GET", "/metrics", nil))
+	body := w.Body.String()
+
+	for _, want := range []string{
+		"space_arena_clients 3\n",
+		"space_arena_send_buffer_max_ratio 0.75\n",
+		"space_arena_send_buffer_backlogged_clients 1\n",
+		"# TYPE space_arena_client_rtt_seconds histogram\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+	if strings.Contains(body, "client=") {
+		t.Errorf("metrics contain per-client labels:\n%s", body)
+	}
+}
@@ -0,0 +1,195 @@
+package server
+
+import (
+	"fmt"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"time"
+)
+
+const (
+	PARTY_MAX_MEMBERS = 4                // 파티 최대 인원
+	PARTY_INVITE_TTL  = time.Second * 60 // 파티 초대 유효 시간
+)
+
+// 함께 매칭되는 클라이언트 그룹: 파티장이 게임 준비하면 모든 파티원이 같은 게임, 같은 팀에 배정됨
+type Party struct {
+	Id       string
+	LeaderId string
+	Members  []*model.Client
+	invites  map[string]time.Time // 초대받은 클라이언트 아이디와 초대 만료 시각
+}
+
+func (p *Party) Info() *model.PartyInfo {
+	info := &model.PartyInfo{Id: p.Id, LeaderId: p.LeaderId, Members: []model.PartyMember{}}
+	for _, c := range p.Members {
+		info.Members = append(info.Members, model.PartyMember{Id: c.Id, Name: c.Name})
+	}
+	return info
+}
+
+func (p *Party) remove(c *model.Client) {
+	for i, m := range p.Members {
+		if m == c {
+			p.Members = append(p.Members[:i], p.Members[i+1:]...)
+			break
+		}
+	}
+	if p.LeaderId == c.Id && len(p.Members) > 0 {
+		// 파티장이 탈퇴하면 가장 먼저 참가한 파티원이 파티장
+		p.LeaderId = p.Members[0].Id
+	}
+}
+
+// 파티 상태를 모든 파티원에게 전송
+func (p *Party) broadcast() {
+	info := p.Info()
+	for _, c := range p.Members {
+		c.AddMsg(model.Msg{ClientId: c.Id, Type: model.MSG_TYPE_PARTY, Party: info})
+	}
+}
+
+// 클라이언트가 참가 중인 파티: s.partyMu를 잠근 상태에서 호출
+func (s *Server) partyOf(c *model.Client) *Party {
+	if c.PartyId == "" {
+		return nil
+	}
+	return s.parties[c.PartyId]
+}
+
+// 파티 초대 메시지 처리: 초대 대상은 클라이언트 아이디(data.id) 또는 계정 이름(data.name)
+// 파티가 없으면 새 파티를 만들고, 파티장만 초대할 수 있음
+func (s *Server) inviteParty(c *model.Client, data model.EventData) {
+	s.partyMu.Lock()
+	defer s.partyMu.Unlock()
+
+	target, err := s.findInviteTarget(c, data)
+	if err != nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, err.Error()))
+		return
+	}
+
+	p := s.partyOf(c)
+	if p == nil {
+		p = &Party{Id: utils.RandomCapAlphaNumeric(10), LeaderId: c.Id, invites: map[string]time.Time{}}
+		p.Members = append(p.Members, c)
+		c.PartyId = p.Id
+		s.parties[p.Id] = p
+	}
+	if p.LeaderId != c.Id {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "only party leader can invite"))
+		return
+	}
+	if target.PartyId == p.Id {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "already in party"))
+		return
+	}
+	if len(p.Members) >= PARTY_MAX_MEMBERS {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "party is full"))
+		return
+	}
+
+	p.invites[target.Id] = time.Now().Add(PARTY_INVITE_TTL)
+	target.AddMsg(model.Msg{
+		ClientId: target.Id, Type: model.MSG_TYPE_PARTY_INVITE, Party: p.Info(),
+		Event: model.Event{OwnerId: c.Id, Data: model.EventData{Name: c.Name}},
+	})
+	p.broadcast()
+}
+
+func (s *Server) findInviteTarget(c *model.Client, data model.EventData) (*model.Client, error) {
+	id := data.Id
+	if id == "" && data.Name != "" {
+		a, err := s.accounts.GetByName(data.Name)
+		if err != nil {
+			return nil, fmt.Errorf("player not found")
+		}
+		id = a.Id
+	}
+	target, ok := s.clients.Get(id)
+	if !ok || target == c {
+		return nil, fmt.Errorf("player not found")
+	}
+	if c.GameId() != "" || target.GameId() != "" {
+		return nil, fmt.Errorf("player is in game")
+	}
+	if s.isBlocked(target.Id, c.Id) {
+		return nil, fmt.Errorf("player is not accepting invites")
+	}
+	return target, nil
+}
+
+// 파티 초대 수락 메시지 처리: data.id는 파티 아이디
+// 참가 중인 다른 파티가 있으면 탈퇴하고, 파티가 대기열에 있으면 수락할 수 없음
+func (s *Server) acceptParty(c *model.Client, partyId string) {
+	s.partyMu.Lock()
+	defer s.partyMu.Unlock()
+
+	p, ok := s.parties[partyId]
+	if !ok {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "party not found"))
+		return
+	}
+	expiredAt, ok := p.invites[c.Id]
+	if !ok || time.Now().After(expiredAt) {
+		delete(p.invites, c.Id)
+		c.AddMsg(model.MakeErrorMsg(c.Id, "invite not found"))
+		return
+	}
+	if c.GameId() != "" || s.queueOf(p.Members[0]) != nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "party is in queue or game"))
+		return
+	}
+	if len(p.Members) >= PARTY_MAX_MEMBERS {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "party is full"))
+		return
+	}
+
+	s.leavePartyLocked(c)
+	s.dequeue(c)
+	delete(p.invites, c.Id)
+	p.Members = append(p.Members, c)
+	c.PartyId = p.Id
+	p.broadcast()
+}
+
+// 파티 탈퇴: 파티가 대기열에 있으면 대기열에서 삭제
+func (s *Server) leaveParty(c *model.Client) {
+	s.partyMu.Lock()
+	defer s.partyMu.Unlock()
+	s.leavePartyLocked(c)
+}
+
+func (s *Server) leavePartyLocked(c *model.Client) {
+	p := s.partyOf(c)
+	c.PartyId = ""
+	if p == nil {
+		return
+	}
+	if c.GameId() == "" {
+		s.dequeue(c)
+	}
+
+	p.remove(c)
+	c.AddMsg(model.Msg{ClientId: c.Id, Type: model.MSG_TYPE_PARTY})
+	if len(p.Members) == 0 {
+		delete(s.parties, p.Id)
+		return
+	}
+	p.broadcast()
+}
+
+// 게임 준비할 클라이언트 그룹: 파티에 참가 중이면 파티장만 준비할 수 있으며 모든 파티원이 함께 준비
+func (s *Server) readyGroup(c *model.Client) ([]*model.Client, error) {
+	s.partyMu.Lock()
+	defer s.partyMu.Unlock()
+
+	p := s.partyOf(c)
+	if p == nil {
+		return []*model.Client{c}, nil
+	}
+	if p.LeaderId != c.Id {
+		return nil, fmt.Errorf("only party leader can ready")
+	}
+	return append([]*model.Client{}, p.Members...), nil
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"space_arena/internal/game"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	DISPLAY_NAME_MIN_LEN = 2
+	DISPLAY_NAME_MAX_LEN = 12
+)
+
+// 표시 이름 사용 가능 여부를 판단하는 필터, false를 반환하면 사용할 수 없음
+type NameFilter func(name string) bool
+
+func (s *Server) SetNameFilter(f NameFilter) {
+	s.nameFilter = f
+}
+
+// 금칙어 파일(한 줄에 하나)을 로드하여, 금칙어가 포함된 이름을 거부하는 필터 생성
+func LoadBlocklistFilter(path string) (NameFilter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("LoadBlocklistFilter: %w", err)
+	}
+	defer f.Close()
+
+	words := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		word := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if word != "" && !strings.HasPrefix(word, "#") {
+			words = append(words, word)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("LoadBlocklistFilter: %w", err)
+	}
+
+	return func(name string) bool {
+		name = strings.ToLower(name)
+		for _, word := range words {
+			if strings.Contains(name, word) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func (s *Server) setupNameFilter(path string) {
+	if path == "" {
+		return
+	}
+	filter, err := LoadBlocklistFilter(path)
+	if err != nil {
+		utils.Fatal("name filter error", "err", err)
+	}
+	s.SetNameFilter(filter)
+}
+
+// 표시 이름 길이 및 문자 검사: 문자, 숫자, 공백, '_', '-'만 허용
+func (s *Server) validateDisplayName(name string) error {
+	n := utf8.RuneCountInString(name)
+	if n < DISPLAY_NAME_MIN_LEN || n > DISPLAY_NAME_MAX_LEN {
+		return fmt.Errorf("name length must be %d-%d", DISPLAY_NAME_MIN_LEN, DISPLAY_NAME_MAX_LEN)
+	}
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("name must not start or end with space")
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '_' && r != '-' {
+			return fmt.Errorf("name contains invalid character: %q", r)
+		}
+	}
+	if s.nameFilter != nil && !s.nameFilter(name) {
+		return fmt.Errorf("name is not allowed")
+	}
+	return nil
+}
+
+func validateSkin(skin int) error {
+	if skin != model.CLIENT_SKIN_DEFAULT && (skin < 0 || skin >= game.PLAYER_SKIN_NUM) {
+		return fmt.Errorf("invalid skin: %d", skin)
+	}
+	return nil
+}
+
+// 프로필(표시 이름, 우주선 스킨) 설정 메시지 처리: 게임 준비 전에만 변경 가능
+func (s *Server) setProfile(c *model.Client, data model.EventData) {
+	if c.GameId() != "" || s.queueOf(c) != nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "profile can only be changed before ready"))
+		return
+	}
+
+	name := strings.TrimSpace(data.Name)
+	if err := s.validateDisplayName(name); err != nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, err.Error()))
+		return
+	}
+	if data.Skin != nil {
+		if err := validateSkin(*data.Skin); err != nil {
+			c.AddMsg(model.MakeErrorMsg(c.Id, err.Error()))
+			return
+		}
+	}
+
+	c.Name = name
+	if data.Skin != nil {
+		c.Skin = *data.Skin
+	}
+	skin := c.Skin
+	c.AddMsg(model.MakeMsg(c.Id, model.MSG_TYPE_PROFILE, model.Event{
+		OwnerId: c.Id, Data: model.EventData{Name: c.Name, Skin: &skin},
+	}))
+
+	// 파티원에게 변경된 표시 이름 전송
+	s.partyMu.Lock()
+	if p := s.partyOf(c); p != nil {
+		p.broadcast()
+	}
+	s.partyMu.Unlock()
+}
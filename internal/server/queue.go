@@ -0,0 +1,209 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"os"
+	"slices"
+	"space_arena/internal/game"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"sync/atomic"
+)
+
+const (
+	QUEUE_DEFAULT = "ffa" // ready 메시지에 대기열을 지정하지 않은 경우 사용하는 대기열
+)
+
+// 매칭 대기열 설정
+type QueueConfig struct {
+	Name       string   `json:"name"`
+	Mode       string   `json:"mode"`        // 게임 모드(ffa, team)
+	PlayerNum  int      `json:"player_num"`  // 게임당 플레이어 수
+	MinPlayers int      `json:"min_players"` // 최대 대기 시간 초과 시 게임을 시작할 수 있는 최소 인원, 0이면 player_num
+	TeamNum    int      `json:"team_num"`    // 팀전의 팀 수
+	Maps       []string `json:"maps"`        // 사용할 맵 이름 목록, 비어 있으면 인원을 수용할 수 있는 모든 맵
+}
+
+func defaultQueues() []QueueConfig {
+	return []QueueConfig{
+		{Name: QUEUE_DEFAULT, Mode: game.GAME_MODE_FFA, PlayerNum: GAME_PLAYER_NUM, MinPlayers: GAME_PLAYER_MIN},
+		{Name: "duel", Mode: game.GAME_MODE_FFA, PlayerNum: 2, MinPlayers: 2},
+		{Name: "team", Mode: game.GAME_MODE_TEAM, PlayerNum: 6, MinPlayers: 4, TeamNum: 2},
+	}
+}
+
+func (qc *QueueConfig) Validate() error {
+	if qc.Name == "" {
+		return fmt.Errorf("queue name is empty")
+	}
+	if qc.PlayerNum < 2 {
+		return fmt.Errorf("queue %s: player_num must be at least 2", qc.Name)
+	}
+	if qc.MinPlayers == 0 {
+		qc.MinPlayers = qc.PlayerNum
+	}
+	if qc.MinPlayers < 2 || qc.MinPlayers > qc.PlayerNum {
+		return fmt.Errorf("queue %s: min_players must be between 2 and player_num", qc.Name)
+	}
+	switch qc.Mode {
+	case game.GAME_MODE_FFA:
+		qc.TeamNum = 0
+	case game.GAME_MODE_TEAM:
+		if qc.TeamNum < 2 || qc.PlayerNum%qc.TeamNum != 0 {
+			return fmt.Errorf("queue %s: team_num must be at least 2 and divide player_num", qc.Name)
+		}
+		if qc.MinPlayers < qc.TeamNum {
+			return fmt.Errorf("queue %s: min_players must be at least team_num", qc.Name)
+		}
+	default:
+		return fmt.Errorf("queue %s: unknown mode %q", qc.Name, qc.Mode)
+	}
+	return nil
+}
+
+// 대기열별 매치메이커, 맵 로테이션 및 진행 중인 인원
+type matchQueue struct {
+	QueueConfig
+	matchmaker *Matchmaker
+	maps       []*game.Map
+	mapIdx     int
+	playing    atomic.Int64
+}
+
+// QUEUE_CONFIG_PATH 환경 변수의 JSON 파일(대기열 설정 배열)로 대기열 생성, 지정하지 않으면 기본 대기열 사용
+func (s *Server) setupQueues(path string) {
+	configs := defaultQueues()
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			utils.Fatal("load queue config error", "err", err)
+		}
+		configs = nil
+		if err := json.Unmarshal(data, &configs); err != nil {
+			utils.Fatal("load queue config error", "err", err)
+		}
+	}
+
+	for _, qc := range configs {
+		if err := qc.Validate(); err != nil {
+			utils.Fatal("queue config error", "err", err)
+		}
+		if s.queue(qc.Name) != nil {
+			utils.Fatal("queue config error: duplicate queue", "queue", qc.Name)
+		}
+
+		q := &matchQueue{QueueConfig: qc, matchmaker: NewMatchmaker(qc.PlayerNum, qc.MinPlayers, qc.TeamNum)}
+		for _, m := range s.maps {
+			if len(qc.Maps) > 0 && !slices.Contains(qc.Maps, m.Name) {
+				continue
+			}
+			if m.MaxPlayers < qc.PlayerNum {
+				if len(qc.Maps) > 0 {
+					utils.Fatal("queue config error: map max_players is less than player_num", "queue", qc.Name, "map", m.Name, "max_players", m.MaxPlayers, "player_num", qc.PlayerNum)
+				}
+				continue
+			}
+			q.maps = append(q.maps, m)
+		}
+		if len(q.maps) == 0 || (len(qc.Maps) > 0 && len(q.maps) != len(qc.Maps)) {
+			utils.Fatal("queue config error: no available map", "queue", qc.Name, "maps", qc.Maps)
+		}
+		s.queues = append(s.queues, q)
+	}
+	if len(s.queues) == 0 {
+		utils.Fatal("queue config error: no queue")
+	}
+	slog.Info("queues loaded", "count", len(s.queues))
+}
+
+// 대기열 이름으로 대기열 검색, 이름이 비어 있으면 기본 대기열(첫 번째 대기열)
+func (s *Server) queue(name string) *matchQueue {
+	if name == "" && len(s.queues) > 0 {
+		return s.queues[0]
+	}
+	for _, q := range s.queues {
+		if q.Name == name {
+			return q
+		}
+	}
+	return nil
+}
+
+// 클라이언트가 대기 중인 대기열
+func (s *Server) queueOf(c *model.Client) *matchQueue {
+	for _, q := range s.queues {
+		if q.matchmaker.Contains(c) {
+			return q
+		}
+	}
+	return nil
+}
+
+// 클라이언트가 속한 그룹을 대기열에서 삭제하고, 그룹의 모든 클라이언트에게 준비 취소 메시지 전송
+func (s *Server) dequeue(c *model.Client) bool {
+	for _, q := range s.queues {
+		if clients := q.matchmaker.Remove(c); clients != nil {
+			for _, tc := range clients {
+				tc.AddMsg(model.MakeMsg(tc.Id, model.MSG_TYPE_CANCEL, model.Event{}))
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// 대기열에 참가할 수 있는 그룹 최대 인원: 팀전이면 팀 인원, 개인전이면 다른 플레이어가 한 명 이상 참가할 수 있는 인원
+func (q *matchQueue) maxGroupSize() int {
+	if q.Mode == game.GAME_MODE_TEAM {
+		return q.PlayerNum / q.TeamNum
+	}
+	return q.PlayerNum - 1
+}
+
+func (s *Server) queueInfos() []model.QueueInfo {
+	infos := []model.QueueInfo{}
+	for _, q := range s.queues {
+		maps := []string{}
+		for _, m := range q.maps {
+			maps = append(maps, m.Name)
+		}
+		infos = append(infos, model.QueueInfo{
+			Name: q.Name, Mode: q.Mode, PlayerNum: q.PlayerNum, TeamNum: q.TeamNum, Maps: maps,
+			Waiting: q.matchmaker.Len(), Playing: int(q.playing.Load()),
+		})
+	}
+	return infos
+}
+
+// 대기열 인원이 변경된 경우 게임에 참여하지 않은 클라이언트에게 대기열 목록 전송
+func (s *Server) broadcastQueues() {
+	infos := s.queueInfos()
+	changed := len(infos) != len(s.lastQueueInfos)
+	for i := 0; !changed && i < len(infos); i++ {
+		changed = infos[i].Waiting != s.lastQueueInfos[i].Waiting || infos[i].Playing != s.lastQueueInfos[i].Playing
+	}
+	if !changed {
+		return
+	}
+	s.lastQueueInfos = infos
+
+	s.clients.Range(func(id string, c *model.Client) bool {
+		if c.GameId() == "" {
+			c.AddMsg(model.Msg{ClientId: id, Type: model.MSG_TYPE_QUEUES, Queues: infos})
+		}
+		return true
+	})
+}
+
+// 맵 로테이션 방식에 따라 다음 게임에 사용할 맵 선택
+func (q *matchQueue) nextMap(rotation string) *game.Map {
+	if rotation == MAP_ROTATION_RANDOM {
+		return q.maps[rand.Intn(len(q.maps))]
+	}
+	m := q.maps[q.mapIdx%len(q.maps)]
+	q.mapIdx++
+	return m
+}
@@ -1,21 +1,38 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/netip"
+	"os"
+	"os/signal"
+	"space_arena/internal/account"
 	"space_arena/internal/game"
 	"space_arena/internal/model"
+	"space_arena/internal/stats"
 	"space_arena/internal/utils"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/websocket"
 )
 
 const (
 	GAME_PLAYER_NUM = 9 // 게임당 최대 9명 플레이 가능
+	GAME_PLAYER_MIN = 2 // 최대 대기 시간을 넘긴 경우 게임을 시작할 수 있는 최소 인원
+)
+
+const (
+	MAP_ROTATION_SEQUENTIAL = "sequential"
+	MAP_ROTATION_RANDOM     = "random"
 )
 
 var upgrader = websocket.Upgrader{
@@ -23,64 +40,190 @@ var upgrader = websocket.Upgrader{
 }
 
 type Server struct {
-	games            *utils.SafeMap[string, *game.Game]
-	clients          *utils.SafeMap[string, *model.Client]
-	recvMsgChan      chan model.Msg
-	clientReadyQueue utils.Queue[*model.Client]
-	clientRemoveMu   sync.Mutex
+	games          *utils.SafeMap[string, *game.Game]
+	clients        *utils.SafeMap[string, *model.Client]
+	recvMsgChan    chan model.Msg
+	queues         []*matchQueue     // 매칭 대기열 목록, 첫 번째 대기열이 기본 대기열
+	lastQueueInfos []model.QueueInfo // 마지막으로 전송한 대기열 목록
+	matchingMu     sync.Mutex
+	parties        map[string]*Party
+	partyMu        sync.Mutex
+	chatStates     *utils.SafeMap[string, *chatState]
+	spectatorChat  bool // true이면 탈락한 플레이어는 관전자 채널에서만 채팅 가능
+	clientRemoveMu sync.Mutex
+	maps           []*game.Map // 맵 로테이션 목록
+	mapRotation    string      // 맵 선택 방식
+	accounts       account.Store
+	tokens         *account.TokenSigner
+	authRequired   bool // 세션 토큰 없이 접속 허용 여부
+	authLimiter    *authLimiter
+	nameFilter     NameFilter // 표시 이름 필터
+	stats          stats.Store
+	violations     *ViolationTracker
+	bans           *BanList
+	trustedProxies []netip.Prefix // X-Forwarded-For 헤더를 신뢰하는 프록시 주소
+	adminToken     string         // 관리자 API 토큰, 비어 있으면 관리자 API 비활성화
+	startedAt      time.Time
+	draining       atomic.Bool   // 종료 대기 중이면 새 연결과 게임 준비를 받지 않음
+	drainTimeout   time.Duration // 종료 시 진행 중인 게임을 기다리는 최대 시간
+	sendPolicy     string        // 전송 버퍼가 가득 찬 클라이언트 처리 방식
+	keepalive      keepaliveConfig
+	flood          floodConfig
+	floodStats     floodStats
+	msgStats       msgStats
 }
 
 func New() *Server {
 	s := &Server{
-		games:       utils.NewSafeMap[string, *game.Game](),
-		clients:     utils.NewSafeMap[string, *model.Client](),
-		recvMsgChan: make(chan model.Msg, 100000),
+		games:         utils.NewSafeMap[string, *game.Game](),
+		clients:       utils.NewSafeMap[string, *model.Client](),
+		recvMsgChan:   make(chan model.Msg, 100000),
+		parties:       map[string]*Party{},
+		chatStates:    utils.NewSafeMap[string, *chatState](),
+		spectatorChat: utils.Getevn("CHAT_DEAD_SPECTATOR_ONLY", "false") == "true",
+		mapRotation:   utils.Getevn("MAP_ROTATION", MAP_ROTATION_SEQUENTIAL),
+		startedAt:     time.Now(),
+	}
+	s.loadMaps(utils.Getevn("MAP_DIR", "./maps"))
+	s.setupQueues(utils.Getevn("QUEUE_CONFIG_PATH", ""))
+	s.setupAuth()
+	s.setupNameFilter(utils.Getevn("NAME_BLOCKLIST_PATH", ""))
+	s.setupStats()
+	s.setupViolations()
+	s.setupBans()
+	s.setupTrustedProxies()
+	s.setupFlood()
+	s.setupKeepalive()
+	drainTimeout, err := time.ParseDuration(utils.Getevn("DRAIN_TIMEOUT", "5m"))
+	if err != nil || drainTimeout < 0 {
+		utils.Fatal("invalid DRAIN_TIMEOUT", "err", err)
+	}
+	s.drainTimeout = drainTimeout
+	s.sendPolicy = utils.Getevn("CLIENT_SEND_POLICY", model.CLIENT_SEND_POLICY_COALESCE)
+	if !model.ValidSendPolicy(s.sendPolicy) {
+		utils.Fatal("invalid CLIENT_SEND_POLICY", "policy", s.sendPolicy)
 	}
 
 	http.Handle("/", http.FileServer(http.Dir("./web")))
 	http.HandleFunc("/ws", s.WsController)
+	http.HandleFunc("GET /metrics", s.MetricsController)
+	http.HandleFunc("GET /healthz", s.HealthController)
+	http.HandleFunc("GET /readyz", s.ReadyController)
+	http.HandleFunc("GET /status", s.StatusController)
+	http.HandleFunc("POST /api/register", s.authLimit(s.RegisterController))
+	http.HandleFunc("POST /api/login", s.authLimit(s.LoginController))
+	http.HandleFunc("GET /api/players/{id}/matches", s.PlayerMatchesController)
+	http.HandleFunc("GET /api/players/{id}/stats", s.PlayerStatsController)
+	http.HandleFunc("GET /api/leaderboard", s.LeaderboardController)
+	http.HandleFunc("GET /api/leaderboard/me", s.LeaderboardRankController)
+	http.HandleFunc("GET /api/admin/clients", s.admin(s.AdminClientsController))
+	http.HandleFunc("POST /api/admin/clients/{id}/kick", s.admin(s.AdminKickController))
+	http.HandleFunc("GET /api/admin/games", s.admin(s.AdminGamesController))
+	http.HandleFunc("POST /api/admin/games/{id}/terminate", s.admin(s.AdminTerminateGameController))
+	http.HandleFunc("GET /api/admin/bans", s.admin(s.AdminBansController))
+	http.HandleFunc("POST /api/admin/bans", s.admin(s.AdminBanController))
+	http.HandleFunc("DELETE /api/admin/bans/{key}", s.admin(s.AdminUnbanController))
 	return s
 }
 
+func (s *Server) loadMaps(dir string) {
+	maps, err := game.LoadMaps(dir)
+	if err != nil {
+		utils.Fatal("load maps error", "err", err)
+	}
+	if len(maps) == 0 {
+		slog.Warn("no map file found, use default map", "dir", dir)
+		maps = append(maps, game.DefaultMap())
+	}
+	s.maps = maps
+	slog.Info("maps loaded", "count", len(s.maps), "rotation", s.mapRotation)
+}
+
 func (s *Server) Run() {
 	go s.msgHandler()
-	log.Println("server on :8080")
-	log.Fatal(http.ListenAndServe(":8080", nil))
+	go s.matchLoop()
+	srv := &http.Server{Addr: ":8080"}
+	go func() {
+		slog.Info("server on :8080")
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			utils.Fatal("server error", "err", err)
+		}
+	}()
+
+	// 종료 신호를 받으면 준비 상태를 해제하고 진행 중인 게임이 끝날 때까지 대기
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	s.drain()
+	s.waitGames(s.drainTimeout)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		slog.Warn("server shutdown error", "err", err)
+	}
+	slog.Info("server stopped")
 }
 
 func (s *Server) WsController(w http.ResponseWriter, r *http.Request) {
-	conn, err := upgrader.Upgrade(w, r, nil)
+	// 세션 토큰 검증 및 클라이언트 아이디 발급
+	id, acc, err := s.authenticate(r)
 	if err != nil {
-		log.Println("websocket upgrader.Upgrade error:", err)
+		slog.Warn("authenticate error", "err", err)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	if s.draining.Load() {
+		http.Error(w, "server is draining", http.StatusServiceUnavailable)
+		return
+	}
+	// 접속 차단 확인
+	addr := s.remoteIP(r)
+	banKeys := []string{ipBanKey(addr)}
+	if acc != nil {
+		banKeys = append(banKeys, accountBanKey(acc.Id))
+	}
+	if ban, ok := s.bans.Check(banKeys...); ok {
+		slog.Info("banned client rejected", "client_id", id, "ban_key", ban.Key, "expires_at", ban.ExpiresAt)
+		http.Error(w, "banned", http.StatusForbidden)
+		return
+	}
+	if _, ok := s.clients.Get(id); ok {
+		// 동일한 계정으로 이미 접속 중
+		slog.Info("client already connected", "client_id", id)
+		http.Error(w, "already connected", http.StatusConflict)
 		return
 	}
 
-	// 클라이언트 아이디 생성
-	id := utils.RandomCapAlphaNumeric(10)
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Warn("websocket upgrader.Upgrade error", "client_id", id, "err", err)
+		return
+	}
 
 	// 최초 패킷 전송
+	conn.SetWriteDeadline(time.Now().Add(s.keepalive.writeTimeout))
 	err = conn.WriteJSON(model.MakeMsg(id, model.MSG_TYPE_HELLO, model.Event{}))
 	if err != nil {
-		log.Println("websocket conn.WriteJSON error:", err)
+		slog.Warn("websocket conn.WriteJSON error", "client_id", id, "err", err)
 		return
 	}
-	log.Println("client connected", id)
+	slog.Info("client connected", "client_id", id, "addr", addr)
 
 	// 클라이언트 등록
-	s.addClient(id, conn)
-	c, _ := s.clients.Get(id)
+	c := s.addClient(id, conn, acc != nil, addr)
+	if acc != nil && s.validateDisplayName(acc.Name) == nil {
+		// 계정 이름을 기본 표시 이름으로 사용
+		c.Name = acc.Name
+	}
 
 	// 게임으로부터 전달받은 메시지를 클라이언트로 전송
-	go func() {
-		for msg := range c.GetMsgChan() {
-			if err := conn.WriteJSON(msg); err != nil {
-				log.Println("ws WriteJSON error:", err)
-				break
-			}
-		}
-	}()
+	go s.writeLoop(c)
 
 	// 클라이언트로부터 수신한 메시지를 게임으로 전달
+	conn.SetReadLimit(s.flood.readLimit)
+	s.watchPong(c)
+	limiter := s.newMsgLimiter(time.Now())
 	for {
 		_, data, err := conn.ReadMessage()
 		if err != nil {
@@ -88,20 +231,50 @@ func (s *Server) WsController(w http.ResponseWriter, r *http.Request) {
 				strings.Contains(err.Error(), "read: connection reset by peer") ||
 				strings.Contains(err.Error(), "websocket: close 1001 (going away)") ||
 				strings.Contains(err.Error(), "websocket: close 1006 (abnormal closure): unexpected EOF") {
-				log.Println("client disconnected", id)
+				slog.Info("client disconnected", "client_id", id, "game_id", c.GameId())
+			} else if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				slog.Info("client timed out", "client_id", id, "game_id", c.GameId())
+			} else if errors.Is(err, websocket.ErrReadLimit) {
+				s.violation(id, "message too large")
 			} else {
-				log.Println("conn.ReadMessage error:", err, id)
+				slog.Warn("conn.ReadMessage error", "client_id", id, "game_id", c.GameId(), "err", err)
 			}
 			break
 		}
+		s.msgStats.in.Inc()
+		conn.SetReadDeadline(time.Now().Add(s.keepalive.pongTimeout))
 		var msg model.Msg
 		if err := json.Unmarshal(data, &msg); err != nil {
-			log.Println("json.Unmarshal error:", err, id)
+			slog.Warn("json.Unmarshal error", "client_id", id, "err", err)
 			break
 		}
+
+		// 메시지 발신자를 연결된 클라이언트로 고정
+		if msg.ClientId != id {
+			if msg.ClientId != "" {
+				s.violation(id, "spoofed client id")
+			}
+			msg.ClientId = id
+		}
+		if msg.Type == model.MSG_TYPE_INGAME && msg.Event.OwnerId != id {
+			if msg.Event.OwnerId != "" {
+				s.violation(id, "spoofed event owner id")
+			}
+			msg.Event.OwnerId = id
+		}
+		now := time.Now()
+		allowed := limiter.allow(msg.Type, now)
+		if msgType, ok := limiter.flood(now); ok {
+			s.violation(id, "message flood: "+msgType)
+		}
+		if !allowed {
+			s.throttle(id, limiter, msg.Type)
+			continue
+		}
 		if err := s.addRecvMsg(msg); err != nil {
-			log.Println("addRecvMsg error:", err, id)
-			break
+			// 수신 채널이 가득 찬 경우 연결을 유지하고 메시지만 버림
+			s.floodStats.dropped.Add(1)
+			slog.Warn("addRecvMsg error", "client_id", id, "err", err)
 		}
 	}
 
@@ -109,21 +282,25 @@ func (s *Server) WsController(w http.ResponseWriter, r *http.Request) {
 	if !ok {
 		return
 	}
-	g, ok := s.games.Get(c.GameId)
+	g, ok := s.games.Get(c.GameId())
 	if ok {
 		// 클라이언트가 참여중인 게임이 있는 경우: 플레이어 삭제 및 연결 해제 이벤트 전송
 		g.DeletePlayer(id)
 	} else {
-		// 아직 참여중인 게임이 없는 경우: 대기 큐에서 삭제
-		s.clientReadyQueue.Remove(c)
+		// 아직 참여중인 게임이 없는 경우: 대기열에서 삭제
+		s.dequeue(c)
 	}
 	// 클라이언트 삭제
 	s.removeClient(id)
 }
 
-func (s *Server) addClient(id string, conn *websocket.Conn) {
+func (s *Server) addClient(id string, conn *websocket.Conn, authenticated bool, addr string) *model.Client {
 	client := model.CreateClient(id, conn)
+	client.Authenticated = authenticated
+	client.Addr = addr
+	client.SendPolicy = s.sendPolicy
 	s.clients.Set(id, client)
+	return client
 }
 
 func (s *Server) removeClient(id string) {
@@ -133,6 +310,11 @@ func (s *Server) removeClient(id string) {
 	if !ok {
 		return
 	}
+	s.leaveParty(c)
+	s.chatStates.Delete(id)
+	if !c.Authenticated {
+		s.violations.forget(clientViolationKey(c))
+	}
 	c.CloseChan()
 	c.Conn.Close()
 	s.clients.Delete(id)
@@ -153,83 +335,167 @@ func (s *Server) msgHandler() {
 			c, ok := s.clients.Get(msg.ClientId)
 			if !ok {
 				// 등록되지 않은 클라이언트
-				log.Println("unregistered client id", msg.ClientId)
+				slog.Warn("unregistered client id", "client_id", msg.ClientId)
 				continue
 			}
 
 			switch msg.Type {
+			// 프로필 설정 메시지
+			case model.MSG_TYPE_PROFILE:
+				s.setProfile(c, msg.Event.Data)
+
 			// 게임 준비 메시지
 			case model.MSG_TYPE_READY:
-				s.clientReadyQueue.Enqueue(c)
-				c.AddMsg(model.MakeMsg(c.Id, model.MSG_TYPE_READY, model.Event{}))
-				s.matching()
+				s.ready(c, msg.Queue)
+
+			// 대기열 목록 요청 메시지
+			case model.MSG_TYPE_QUEUES:
+				c.AddMsg(model.Msg{ClientId: c.Id, Type: model.MSG_TYPE_QUEUES, Queues: s.queueInfos()})
 
-			// 게임 준비 취소 메시지
+			// 게임 준비 취소 메시지: 파티원 중 누구나 파티 전체의 준비를 취소할 수 있음
 			case model.MSG_TYPE_CANCEL:
-				if ok := s.clientReadyQueue.Remove(c); !ok {
+				if ok := s.dequeue(c); !ok {
 					c.AddMsg(model.MakeMsg(c.Id, model.MSG_TYPE_ERROR, model.Event{}))
-				} else {
-					c.AddMsg(model.MakeMsg(c.Id, model.MSG_TYPE_CANCEL, model.Event{}))
 				}
 
+			// 파티 메시지
+			case model.MSG_TYPE_PARTY_INVITE:
+				s.inviteParty(c, msg.Event.Data)
+			case model.MSG_TYPE_PARTY_ACCEPT:
+				s.acceptParty(c, msg.Event.Data.Id)
+			case model.MSG_TYPE_PARTY_LEAVE:
+				s.leaveParty(c)
+
+			// 채팅 메시지
+			case model.MSG_TYPE_CHAT:
+				s.chat(c, msg.Chat)
+			case model.MSG_TYPE_MUTE, model.MSG_TYPE_UNMUTE, model.MSG_TYPE_BLOCK, model.MSG_TYPE_UNBLOCK:
+				s.setChatFilter(c, msg.Type, msg.Event.Data.Id)
+
 			// 인게임 메시지
 			case model.MSG_TYPE_INGAME:
-				g, ok := s.games.Get(c.GameId)
+				// 연결 해제 이벤트는 서버에서만 생성
+				if msg.Event.Type == model.EVENT_TYPE_PLAYER_DISCONNECT {
+					s.violation(c.Id, "client sent disconnect event")
+					break
+				}
+				g, ok := s.games.Get(c.GameId())
 				if ok {
-					g.AddEvent(msg.Event)
+					if err := g.AddEvent(msg.Event); err != nil {
+						s.floodStats.dropped.Add(1)
+						slog.Warn("AddEvent error", "client_id", c.Id, "game_id", c.GameId(), "err", err)
+					}
 				}
+
+			default:
+				s.violation(c.Id, "unknown message type: "+msg.Type)
 			}
 		}
 	}()
 }
 
-func (s *Server) matching() {
-	// 게임을 시작하기에 플레이어 수가 충분하지 않음
-	if s.clientReadyQueue.Len() < GAME_PLAYER_NUM {
+// 지정한 대기열에 클라이언트 추가
+func (s *Server) ready(c *model.Client, queueName string) {
+	if s.draining.Load() {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "server is draining"))
 		return
 	}
-
-	// 클라이언트 ready 큐에서 플레이어 모집
-	gameId := utils.RandomCapAlphaNumeric(10)
-	matchingClient := []*model.Client{}
-	for range GAME_PLAYER_NUM {
-		c, ok := s.clientReadyQueue.Dequeue()
-		if !ok {
-			log.Println("clientReadyQueue Dequeue not ok", gameId)
-			break
+	q := s.queue(queueName)
+	if q == nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "unknown queue"))
+		return
+	}
+	clients, err := s.readyGroup(c)
+	if err != nil {
+		c.AddMsg(model.MakeErrorMsg(c.Id, err.Error()))
+		return
+	}
+	if len(clients) > q.maxGroupSize() {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "party is too large for queue"))
+		return
+	}
+	rating := 0.0
+	for _, gc := range clients {
+		if gc.GameId() != "" {
+			c.AddMsg(model.MakeErrorMsg(c.Id, "already in game"))
+			return
 		}
-		c.GameId = gameId
-		matchingClient = append(matchingClient, c)
+		if s.queueOf(gc) != nil {
+			c.AddMsg(model.MakeErrorMsg(c.Id, "already queued"))
+			return
+		}
+		rating += s.stats.PlayerRating(gc.Id)
 	}
 
-	// 모든 클라이언트 매칭에 실패한 경우
-	if len(matchingClient) != GAME_PLAYER_NUM {
-		for i := len(matchingClient) - 1; i >= 0; i-- {
-			// 클라이언트 ready 큐의 맨 앞에 다시 추가
-			s.clientReadyQueue.PushFront(matchingClient[i])
-		}
+	// 그룹의 평균 레이팅으로 매칭: 확인 후 다른 요청으로 이미 대기열에 추가된 경우 실패
+	if !q.matchmaker.Enqueue(clients, rating/float64(len(clients))) {
+		c.AddMsg(model.MakeErrorMsg(c.Id, "already queued"))
 		return
 	}
+	for _, gc := range clients {
+		gc.AddMsg(model.Msg{ClientId: gc.Id, Type: model.MSG_TYPE_READY, Queue: q.Name})
+	}
+	s.matching()
+}
+
+func (s *Server) matchLoop() {
+	ticker := time.NewTicker(MATCH_INTERVAL)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.matching()
+		s.broadcastQueues()
+	}
+}
 
+func (s *Server) matching() {
+	s.matchingMu.Lock()
+	defer s.matchingMu.Unlock()
+
+	// 대기열별로 매치메이커에서 매칭된 클라이언트 그룹마다 게임 시작
+	now := time.Now()
+	for _, q := range s.queues {
+		for _, group := range q.matchmaker.Match(now) {
+			s.startGame(q, group)
+		}
+	}
+}
+
+func (s *Server) startGame(q *matchQueue, group matchGroup) {
+	matchingClient := group.clients
+	gameId := utils.RandomCapAlphaNumeric(10)
+	for _, c := range matchingClient {
+		c.SetGameId(gameId)
+	}
+
+	gameMap := q.nextMap(s.mapRotation)
+	q.playing.Add(int64(len(matchingClient)))
 	go func() {
+		defer q.playing.Add(-int64(len(matchingClient)))
+
 		// 클라이언트에게 게임 시작 메시지 전송
 		for _, c := range matchingClient {
 			if _, ok := s.clients.Get(c.Id); ok {
-				c.AddMsg(model.MakeMsg(c.Id, model.MSG_TYPE_START, model.Event{}))
+				c.AddMsg(model.MakeMsg(c.Id, model.MSG_TYPE_START, model.Event{Data: model.EventData{Id: gameId}}))
 			}
 		}
 
 		// 게임 생성
-		g := game.NewGame(gameId, matchingClient)
+		g := game.NewGame(gameId, matchingClient, gameMap, group.teams)
+		g.SetViolationHandler(s.violation)
 		s.games.Set(gameId, g)
-		log.Println("game start", gameId, s.games.Len())
+		slog.Info("game start", "game_id", gameId, "queue", q.Name, "map", gameMap.Name, "players", len(matchingClient), "games", s.games.Len())
 
 		// 게임 시작
 		g.Run()
 
 		// 게임 종료
 		s.games.Delete(gameId)
-		log.Println("game end", gameId, s.games.Len())
+		slog.Info("game end", "game_id", gameId, "stopped", g.Stopped(), "games", s.games.Len())
+
+		// 게임 결과 저장: 강제 종료된 게임은 저장하지 않음
+		if !g.Stopped() {
+			s.recordMatch(g.Result())
+		}
 
 		// 클라이언트 삭제
 		for _, c := range matchingClient {
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"space_arena/internal/game"
+	"space_arena/internal/stats"
+	"space_arena/internal/utils"
+	"strconv"
+)
+
+const (
+	STATS_PAGE_DEFAULT_LIMIT = 20
+	STATS_PAGE_MAX_LIMIT     = 100
+)
+
+type playerMatchesResponse struct {
+	PlayerId string               `json:"player_id"`
+	Total    int                  `json:"total"`
+	Offset   int                  `json:"offset"`
+	Limit    int                  `json:"limit"`
+	Matches  []*stats.MatchRecord `json:"matches"`
+}
+
+func (s *Server) setupStats() {
+	path := utils.Getevn("STATS_STORE_PATH", "./data/matches.jsonl")
+	if path == "memory" {
+		s.stats = stats.NewMemoryStore()
+		return
+	}
+	store, err := stats.NewFileStore(path)
+	if err != nil {
+		utils.Fatal("stats store error", "err", err)
+	}
+	s.stats = store
+}
+
+// 게임 결과를 매치 기록으로 변환하여 저장: 계정으로 참가한 플레이어가 없으면 저장하지 않음
+func (s *Server) recordMatch(result game.MatchResult) {
+	authenticated := false
+	for _, p := range result.Players {
+		authenticated = authenticated || p.Authenticated
+	}
+	if !authenticated {
+		return
+	}
+	m := &stats.MatchRecord{
+		Id:        result.GameId,
+		MapName:   result.MapName,
+		Mode:      result.Mode,
+		StartedAt: result.StartedAt,
+		Duration:  result.Duration.Seconds(),
+	}
+	for _, p := range result.Players {
+		m.Participants = append(m.Participants, stats.Participant{
+			PlayerId:     p.Id,
+			Name:         p.Name,
+			Guest:        !p.Authenticated,
+			Team:         p.Team,
+			Placement:    p.Placement,
+			Kills:        p.Kills,
+			ShotsFired:   p.ShotsFired,
+			ShotsHit:     p.ShotsHit,
+			Accuracy:     stats.Accuracy(p.ShotsFired, p.ShotsHit),
+			SurvivalTime: p.SurvivalTime,
+			KilledBy:     p.KillerId,
+		})
+	}
+	if err := s.stats.AddMatch(m); err != nil {
+		slog.Error("stats.AddMatch error", "game_id", m.Id, "err", err)
+	}
+}
+
+func (s *Server) PlayerMatchesController(w http.ResponseWriter, r *http.Request) {
+	offset, limit, ok := readPage(w, r)
+	if !ok {
+		return
+	}
+	id := r.PathValue("id")
+	matches, total := s.stats.PlayerMatches(id, offset, limit)
+	writeJSON(w, http.StatusOK, playerMatchesResponse{
+		PlayerId: id, Total: total, Offset: offset, Limit: limit, Matches: matches,
+	})
+}
+
+func (s *Server) PlayerStatsController(w http.ResponseWriter, r *http.Request) {
+	ps, ok := s.stats.PlayerStats(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "player not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, ps)
+}
+
+// 페이지 요청 파라미터(offset, limit) 파싱
+func readPage(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	offset, limit := 0, STATS_PAGE_DEFAULT_LIMIT
+	var err error
+	if v := r.URL.Query().Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "invalid offset")
+			return 0, 0, false
+		}
+	}
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 || limit > STATS_PAGE_MAX_LIMIT {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return 0, 0, false
+		}
+	}
+	return offset, limit, true
+}
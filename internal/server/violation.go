@@ -0,0 +1,150 @@
+package server
+
+import (
+	"log/slog"
+	"space_arena/internal/model"
+	"space_arena/internal/utils"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	VIOLATION_RESET = time.Minute * 10 // 마지막 위반 후 이 시간이 지나면 위반 횟수 초기화
+	VIOLATION_PRUNE = time.Minute      // 초기화된 위반 기록 정리 주기
+)
+
+type violationRecord struct {
+	count int
+	last  time.Time
+}
+
+// 클라이언트의 프로토콜 위반 기록: 계정 또는 연결 단위로 누적되며, 임계값을 넘으면 연결 해제 및 접속 차단
+type ViolationTracker struct {
+	mu            sync.Mutex
+	records       map[string]*violationRecord
+	lastPrune     time.Time
+	kickThreshold int           // 이 횟수 이상 위반하면 연결 해제, 0이면 해제하지 않음
+	banThreshold  int           // 이 횟수 이상 위반하면 접속 차단, 0이면 차단하지 않음
+	banDuration   time.Duration // 접속 차단 기간
+	ipBan         bool          // 토큰 없이 접속한 클라이언트의 위반을 IP 단위로도 누적하여 IP 차단
+}
+
+func (s *Server) setupViolations() {
+	kick, err := strconv.Atoi(utils.Getevn("VIOLATION_KICK_THRESHOLD", "10"))
+	if err != nil || kick < 0 {
+		utils.Fatal("invalid VIOLATION_KICK_THRESHOLD", "err", err)
+	}
+	ban, err := strconv.Atoi(utils.Getevn("VIOLATION_BAN_THRESHOLD", "30"))
+	if err != nil || ban < 0 {
+		utils.Fatal("invalid VIOLATION_BAN_THRESHOLD", "err", err)
+	}
+	duration, err := time.ParseDuration(utils.Getevn("VIOLATION_BAN_DURATION", "1h"))
+	if err != nil || duration <= 0 {
+		utils.Fatal("invalid VIOLATION_BAN_DURATION", "err", err)
+	}
+	s.violations = &ViolationTracker{
+		records:       map[string]*violationRecord{},
+		kickThreshold: kick,
+		banThreshold:  ban,
+		banDuration:   duration,
+		ipBan:         utils.Getevn("VIOLATION_IP_BAN", "false") == "true",
+	}
+}
+
+// 위반 횟수를 기록하고 누적 위반 횟수 반환
+func (vt *ViolationTracker) add(key string) int {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	vt.prune(time.Now())
+	rec, ok := vt.records[key]
+	if !ok || time.Since(rec.last) > VIOLATION_RESET {
+		rec = &violationRecord{}
+		vt.records[key] = rec
+	}
+	rec.count++
+	rec.last = time.Now()
+	return rec.count
+}
+
+// 초기화되지 않은 누적 위반 횟수 반환
+func (vt *ViolationTracker) count(key string) int {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	vt.prune(time.Now())
+	rec, ok := vt.records[key]
+	if !ok || time.Since(rec.last) > VIOLATION_RESET {
+		return 0
+	}
+	return rec.count
+}
+
+// 초기화 시간이 지난 위반 기록 삭제: 계정과 IP 기록은 연결이 끊겨도 유지되므로 주기적으로 정리
+func (vt *ViolationTracker) prune(now time.Time) {
+	if now.Sub(vt.lastPrune) < VIOLATION_PRUNE {
+		return
+	}
+	for key, rec := range vt.records {
+		if now.Sub(rec.last) > VIOLATION_RESET {
+			delete(vt.records, key)
+		}
+	}
+	vt.lastPrune = now
+}
+
+func (vt *ViolationTracker) forget(key string) {
+	vt.mu.Lock()
+	defer vt.mu.Unlock()
+	delete(vt.records, key)
+}
+
+// 위반 기록 키: 계정으로 접속하면 계정, 아니면 연결마다 새로 발급되는 클라이언트 아이디
+// 같은 IP를 공유하는 다른 사용자(프록시, NAT)가 함께 제재되지 않도록 게스트는 IP로 누적하지 않음
+func clientViolationKey(c *model.Client) string {
+	if c.Authenticated {
+		return accountBanKey(c.Id)
+	}
+	return "client:" + c.Id
+}
+
+// 클라이언트의 프로토콜 위반 처리: 게임의 위반 처리 콜백으로도 사용되므로 여러 고루틴에서 호출될 수 있음
+func (s *Server) violation(id, reason string) {
+	c, ok := s.clients.Get(id)
+	if !ok {
+		return
+	}
+	vt := s.violations
+	key := clientViolationKey(c)
+	count := vt.add(key)
+	slog.Warn("violation", "client_id", id, "game_id", c.GameId(), "violation_key", key, "reason", reason, "count", count)
+
+	// 차단 대상: 계정, 게스트는 IP 차단을 사용하는 경우에만 IP
+	banKey, banCount := "", 0
+	if c.Authenticated {
+		banKey, banCount = key, count
+	} else if vt.ipBan {
+		banKey = ipBanKey(c.Addr)
+		banCount = vt.add(banKey)
+	}
+
+	if banKey != "" && vt.banThreshold > 0 && banCount >= vt.banThreshold {
+		ban, err := s.bans.Add(banKey, "violation: "+reason, vt.banDuration)
+		if err != nil {
+			slog.Error("BanList.Add error", "client_id", id, "err", err)
+		}
+		slog.Warn("client banned", "client_id", id, "ban_key", banKey, "expires_at", ban.ExpiresAt)
+		s.kick(c, "banned")
+	} else if vt.kickThreshold > 0 && count >= vt.kickThreshold {
+		s.kick(c, "too many violations")
+	}
+}
+
+// 클라이언트 연결 해제: 연결이 끊기면 WsController에서 게임 및 대기열 정리
+func (s *Server) kick(c *model.Client, reason string) {
+	slog.Warn("client kicked", "client_id", c.Id, "game_id", c.GameId(), "reason", reason)
+	deadline := time.Now().Add(time.Second)
+	c.Conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason), deadline)
+	c.Conn.Close()
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+// 초기화 시간이 지난 계정, IP 기록은 다음 위반 기록 시 삭제됨
+func TestViolationTrackerPrune(t *testing.T) {
+	vt := &ViolationTracker{records: map[string]*violationRecord{}}
+	vt.add(accountBanKey("OLD"))
+	vt.add(ipBanKey("10.0.0.1"))
+	vt.add(accountBanKey("RECENT"))
+	past := time.Now().Add(-VIOLATION_RESET - time.Second)
+	vt.records[accountBanKey("OLD")].last = past
+	vt.records[ipBanKey("10.0.0.1")].last = past
+	vt.lastPrune = time.Now().Add(-VIOLATION_PRUNE)
+
+	if count := vt.add(ipBanKey("10.0.0.2")); count != 1 {
+		t.Fatalf("count = %d, want 1", count)
+	}
+	if len(vt.records) != 2 {
+		t.Errorf("records = %d, want 2 (RECENT, 10.0.0.2)", len(vt.records))
+	}
+	if _, ok := vt.records[accountBanKey("OLD")]; ok {
+		t.Error("expired account record not pruned")
+	}
+	if vt.count(accountBanKey("RECENT")) != 1 {
+		t.Error("recent record pruned")
+	}
+}
@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	SEASON_GLOBAL = "global"  // 전체 기간
+	SEASON_FORMAT = "2006-01" // 시즌은 UTC 기준 월 단위
+)
+
+// 리더보드 정렬 기준
+const (
+	LEADERBOARD_SORT_RATING   = "rating"
+	LEADERBOARD_SORT_WINS     = "wins"
+	LEADERBOARD_SORT_KILLS    = "kills"
+	LEADERBOARD_SORT_SURVIVAL = "survival"
+)
+
+var leaderboardValues = map[string]func(ps *PlayerStats) float64{
+	LEADERBOARD_SORT_RATING:   func(ps *PlayerStats) float64 { return ps.Rating },
+	LEADERBOARD_SORT_WINS:     func(ps *PlayerStats) float64 { return float64(ps.Wins) },
+	LEADERBOARD_SORT_KILLS:    func(ps *PlayerStats) float64 { return float64(ps.Kills) },
+	LEADERBOARD_SORT_SURVIVAL: func(ps *PlayerStats) float64 { return ps.TotalSurvivalTime },
+}
+
+type LeaderboardEntry struct {
+	Rank int `json:"rank"` // 값이 같으면 같은 순위
+	PlayerStats
+}
+
+// 매치 시작 시각이 속한 시즌
+func SeasonOf(t time.Time) string {
+	return t.UTC().Format(SEASON_FORMAT)
+}
+
+func CurrentSeason() string {
+	return SeasonOf(time.Now())
+}
+
+func ValidLeaderboardSort(sortBy string) bool {
+	_, ok := leaderboardValues[sortBy]
+	return ok
+}
+
+// 시즌 이름 검증: global 또는 YYYY-MM
+func ValidSeason(season string) bool {
+	if season == SEASON_GLOBAL {
+		return true
+	}
+	_, err := time.Parse(SEASON_FORMAT, season)
+	return err == nil
+}
+
+// 계정 플레이어의 통계를 정렬 기준에 따라 순위를 매겨 반환
+// 값이 같으면 레이팅, 플레이어 아이디 순으로 정렬
+func buildLeaderboard(stats map[string]*PlayerStats, sortBy string) []LeaderboardEntry {
+	value := leaderboardValues[sortBy]
+
+	players := []*PlayerStats{}
+	for _, ps := range stats {
+		if !ps.guest {
+			players = append(players, ps)
+		}
+	}
+	sort.Slice(players, func(i, j int) bool {
+		vi, vj := value(players[i]), value(players[j])
+		if vi != vj {
+			return vi > vj
+		}
+		if players[i].Rating != players[j].Rating {
+			return players[i].Rating > players[j].Rating
+		}
+		return players[i].PlayerId < players[j].PlayerId
+	})
+
+	entries := make([]LeaderboardEntry, len(players))
+	for i, ps := range players {
+		rank := i + 1
+		if i > 0 && value(ps) == value(players[i-1]) {
+			rank = entries[i-1].Rank
+		}
+		entries[i] = LeaderboardEntry{Rank: rank, PlayerStats: *ps}
+	}
+	return entries
+}
@@ -0,0 +1,39 @@
+package stats
+
+import "math"
+
+const (
+	RATING_DEFAULT = 1500
+	RATING_K       = 32  // 매치당 최대 레이팅 변동폭
+	RATING_SCALE   = 400 // 레이팅 차이에 따른 기대 승률 계산 기준
+)
+
+// 개인전 순위를 모든 참가자 간의 1:1 대결 결과로 보고 Elo 레이팅을 갱신
+// 순위가 같으면 무승부로 처리하며, 변동폭은 상대 수로 나누어 참가자 수와 관계없이 K 이내로 유지
+func UpdateRatings(ratings []float64, placements []int) []float64 {
+	n := len(ratings)
+	result := make([]float64, n)
+	copy(result, ratings)
+	if n < 2 {
+		return result
+	}
+
+	for i := range n {
+		delta := 0.0
+		for j := range n {
+			if i == j {
+				continue
+			}
+			score := 0.5
+			if placements[i] < placements[j] {
+				score = 1
+			} else if placements[i] > placements[j] {
+				score = 0
+			}
+			expected := 1 / (1 + math.Pow(10, (ratings[j]-ratings[i])/RATING_SCALE))
+			delta += score - expected
+		}
+		result[i] += RATING_K * delta / float64(n-1)
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUpdateRatings(t *testing.T) {
+	tests := []struct {
+		name       string
+		ratings    []float64
+		placements []int
+		want       []float64 // nil이면 변동 합만 검사
+	}{
+		{name: "equal duel", ratings: []float64{1500, 1500}, placements: []int{1, 2}, want: []float64{1516, 1484}},
+		{name: "equal duel tie", ratings: []float64{1500, 1500}, placements: []int{1, 1}, want: []float64{1500, 1500}},
+		{name: "tie favors lower rating", ratings: []float64{1700, 1300}, placements: []int{1, 1}},
+		{name: "equal ffa", ratings: []float64{1500, 1500, 1500}, placements: []int{1, 2, 3}, want: []float64{1516, 1500, 1484}},
+		{name: "ffa with tie", ratings: []float64{1500, 1500, 1500, 1500}, placements: []int{1, 2, 2, 4}},
+		{name: "ffa upset", ratings: []float64{1200, 1500, 1800, 1650}, placements: []int{1, 3, 4, 2}},
+		{name: "single player", ratings: []float64{1500}, placements: []int{1}, want: []float64{1500}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UpdateRatings(tt.ratings, tt.placements)
+			// 참가자 간 1:1 대결의 합이므로 전체 레이팅 변동 합은 0
+			sum := 0.0
+			for i := range got {
+				sum += got[i] - tt.ratings[i]
+			}
+			if math.Abs(sum) > 1e-9 {
+				t.Errorf("rating changes sum to %v, want 0: %v", sum, got)
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-9 {
+					t.Errorf("rating[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+			// 순위가 높을수록 변동이 크거나 같음
+			for i := range got {
+				for j := range got {
+					if tt.placements[i] < tt.placements[j] && tt.ratings[i] == tt.ratings[j] && got[i] <= got[j] {
+						t.Errorf("placement %d gained %v, not more than placement %d", tt.placements[i], got[i], tt.placements[j])
+					}
+					if tt.placements[i] == tt.placements[j] && tt.ratings[i] == tt.ratings[j] && got[i] != got[j] {
+						t.Errorf("tied players changed differently: %v", got)
+					}
+				}
+			}
+		})
+	}
+}
+
+// 무승부는 레이팅이 낮은 쪽이 오르고 높은 쪽이 내려감
+func TestUpdateRatingsTieDirection(t *testing.T) {
+	got := UpdateRatings([]float64{1700, 1300}, []int{1, 1})
+	if got[0] >= 1700 || got[1] <= 1300 {
+		t.Errorf("tie result = %v, want higher rating to drop and lower rating to rise", got)
+	}
+}
@@ -0,0 +1,91 @@
+package stats
+
+import (
+	"time"
+)
+
+type MatchRecord struct {
+	Id           string        `json:"id"`
+	MapName      string        `json:"map_name"`
+	Mode         string        `json:"mode,omitempty"`
+	StartedAt    time.Time     `json:"started_at"`
+	Duration     float64       `json:"duration"` // 게임 진행 시간(sec)
+	Participants []Participant `json:"participants"`
+}
+
+type Participant struct {
+	PlayerId     string  `json:"player_id"`
+	Name         string  `json:"name"`
+	Guest        bool    `json:"guest"` // 계정 없이 참가한 플레이어 여부
+	Team         int     `json:"team"`
+	Placement    int     `json:"placement"`
+	Kills        int     `json:"kills"`
+	ShotsFired   int     `json:"shots_fired"`
+	ShotsHit     int     `json:"shots_hit"`
+	Accuracy     float64 `json:"accuracy"`
+	SurvivalTime float64 `json:"survival_time"` // 생존 시간(sec)
+	KilledBy     string  `json:"killed_by,omitempty"`
+	RatingBefore float64 `json:"rating_before"`
+	RatingAfter  float64 `json:"rating_after"`
+}
+
+// 플레이어별 누적 통계
+type PlayerStats struct {
+	PlayerId          string    `json:"player_id"`
+	Name              string    `json:"name"`
+	Matches           int       `json:"matches"`
+	Wins              int       `json:"wins"`
+	Kills             int       `json:"kills"`
+	Deaths            int       `json:"deaths"`
+	ShotsFired        int       `json:"shots_fired"`
+	ShotsHit          int       `json:"shots_hit"`
+	Accuracy          float64   `json:"accuracy"`
+	TotalSurvivalTime float64   `json:"total_survival_time"`
+	AvgPlacement      float64   `json:"avg_placement"`
+	Rating            float64   `json:"rating"`
+	LastPlayedAt      time.Time `json:"last_played_at"`
+	placementSum      int
+	guest             bool
+}
+
+// 매치 결과 저장소
+type Store interface {
+	AddMatch(m *MatchRecord) error
+	// 플레이어의 매치 기록을 최신순으로 반환하고, 전체 매치 수를 함께 반환
+	PlayerMatches(playerId string, offset, limit int) ([]*MatchRecord, int)
+	PlayerStats(playerId string) (PlayerStats, bool)
+	// 플레이어의 현재 레이팅, 기록이 없으면 RATING_DEFAULT
+	PlayerRating(playerId string) float64
+	// 시즌(global 또는 YYYY-MM) 리더보드를 정렬 기준에 따라 반환하고, 전체 플레이어 수를 함께 반환
+	Leaderboard(season, sortBy string, offset, limit int) ([]LeaderboardEntry, int)
+	// 시즌 리더보드에서 플레이어의 순위
+	LeaderboardRank(season, sortBy, playerId string) (LeaderboardEntry, bool)
+}
+
+func Accuracy(shotsFired, shotsHit int) float64 {
+	if shotsFired == 0 {
+		return 0
+	}
+	return float64(shotsHit) / float64(shotsFired)
+}
+
+func (ps *PlayerStats) add(m *MatchRecord, pt *Participant) {
+	ps.Name = pt.Name
+	ps.guest = pt.Guest
+	ps.Matches++
+	if pt.Placement == 1 {
+		ps.Wins++
+	} else {
+		ps.Deaths++
+	}
+	ps.Kills += pt.Kills
+	ps.ShotsFired += pt.ShotsFired
+	ps.ShotsHit += pt.ShotsHit
+	ps.Accuracy = Accuracy(ps.ShotsFired, ps.ShotsHit)
+	ps.TotalSurvivalTime += pt.SurvivalTime
+	ps.placementSum += pt.Placement
+	ps.AvgPlacement = float64(ps.placementSum) / float64(ps.Matches)
+	if m.StartedAt.After(ps.LastPlayedAt) {
+		ps.LastPlayedAt = m.StartedAt
+	}
+}
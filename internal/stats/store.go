@@ -0,0 +1,219 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// 메모리 기반 매치 결과 저장소
+type MemoryStore struct {
+	mu       sync.RWMutex
+	byPlayer map[string][]*MatchRecord // 플레이어별 매치 기록(오래된 순)
+	stats    map[string]*PlayerStats
+	seasons  map[string]map[string]*PlayerStats // 시즌별 플레이어 통계
+	boards   map[string][]LeaderboardEntry      // 정렬된 리더보드 캐시(시즌/정렬 기준), 매치가 추가되면 초기화
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		byPlayer: make(map[string][]*MatchRecord),
+		stats:    make(map[string]*PlayerStats),
+		seasons:  make(map[string]map[string]*PlayerStats),
+		boards:   make(map[string][]LeaderboardEntry),
+	}
+}
+
+func (ms *MemoryStore) AddMatch(m *MatchRecord) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.addMatch(m)
+	return nil
+}
+
+// 매치 결과를 계정 참가자별로 집계하고, 참가자의 레이팅 변동을 매치 기록에 기록
+// 게스트는 매치 기록에만 남고 항상 초기 레이팅으로 계산
+func (ms *MemoryStore) addMatch(m *MatchRecord) {
+	ratings := make([]float64, len(m.Participants))
+	placements := make([]int, len(m.Participants))
+	for i, pt := range m.Participants {
+		ratings[i] = RATING_DEFAULT
+		if !pt.Guest {
+			ratings[i] = ms.rating(pt.PlayerId)
+		}
+		placements[i] = pt.Placement
+	}
+	newRatings := UpdateRatings(ratings, placements)
+
+	for i := range m.Participants {
+		m.Participants[i].RatingBefore = ratings[i]
+		m.Participants[i].RatingAfter = newRatings[i]
+	}
+
+	season := ms.seasons[SeasonOf(m.StartedAt)]
+	if season == nil {
+		season = make(map[string]*PlayerStats)
+		ms.seasons[SeasonOf(m.StartedAt)] = season
+	}
+	for i := range m.Participants {
+		pt := &m.Participants[i]
+		if pt.Guest {
+			// 게스트 아이디는 연결마다 새로 발급되므로 플레이어별로 집계하지 않음
+			continue
+		}
+		ms.byPlayer[pt.PlayerId] = append(ms.byPlayer[pt.PlayerId], m)
+
+		ps, ok := ms.stats[pt.PlayerId]
+		if !ok {
+			ps = &PlayerStats{PlayerId: pt.PlayerId}
+			ms.stats[pt.PlayerId] = ps
+		}
+		ps.add(m, pt)
+		ps.Rating = pt.RatingAfter
+
+		// 시즌 레이팅은 초기값에서 시즌 중 레이팅 변동을 누적
+		sps, ok := season[pt.PlayerId]
+		if !ok {
+			sps = &PlayerStats{PlayerId: pt.PlayerId, Rating: RATING_DEFAULT}
+			season[pt.PlayerId] = sps
+		}
+		sps.add(m, pt)
+		sps.Rating += pt.RatingAfter - pt.RatingBefore
+	}
+	clear(ms.boards)
+}
+
+func (ms *MemoryStore) PlayerRating(playerId string) float64 {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.rating(playerId)
+}
+
+func (ms *MemoryStore) rating(playerId string) float64 {
+	if ps, ok := ms.stats[playerId]; ok {
+		return ps.Rating
+	}
+	return RATING_DEFAULT
+}
+
+func (ms *MemoryStore) PlayerMatches(playerId string, offset, limit int) ([]*MatchRecord, int) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	matches := ms.byPlayer[playerId]
+	total := len(matches)
+	result := []*MatchRecord{}
+	for i := total - 1 - offset; i >= 0 && len(result) < limit; i-- {
+		result = append(result, matches[i])
+	}
+	return result, total
+}
+
+func (ms *MemoryStore) PlayerStats(playerId string) (PlayerStats, bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	ps, ok := ms.stats[playerId]
+	if !ok {
+		return PlayerStats{}, false
+	}
+	return *ps, true
+}
+
+func (ms *MemoryStore) Leaderboard(season, sortBy string, offset, limit int) ([]LeaderboardEntry, int) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	board := ms.leaderboard(season, sortBy)
+	result := []LeaderboardEntry{}
+	for i := offset; i < len(board) && len(result) < limit; i++ {
+		result = append(result, board[i])
+	}
+	return result, len(board)
+}
+
+func (ms *MemoryStore) LeaderboardRank(season, sortBy, playerId string) (LeaderboardEntry, bool) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	for _, e := range ms.leaderboard(season, sortBy) {
+		if e.PlayerId == playerId {
+			return e, true
+		}
+	}
+	return LeaderboardEntry{}, false
+}
+
+// 캐시된 리더보드를 반환하고, 없으면 새로 정렬하여 캐시
+func (ms *MemoryStore) leaderboard(season, sortBy string) []LeaderboardEntry {
+	key := season + "/" + sortBy
+	if board, ok := ms.boards[key]; ok {
+		return board
+	}
+	stats := ms.stats
+	if season != SEASON_GLOBAL {
+		stats = ms.seasons[season]
+	}
+	board := buildLeaderboard(stats, sortBy)
+	ms.boards[key] = board
+	return board
+}
+
+// JSON Lines 파일 기반 매치 결과 저장소: 매치 결과를 파일 끝에 추가하고, 시작 시 전체를 다시 집계
+type FileStore struct {
+	*MemoryStore
+	file *os.File
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	fs := &FileStore{MemoryStore: NewMemoryStore()}
+
+	// 기존 매치 결과 로드
+	f, err := os.Open(path)
+	if err == nil {
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for line := 1; scanner.Scan(); line++ {
+			m := &MatchRecord{}
+			if err := json.Unmarshal(scanner.Bytes(), m); err != nil {
+				return nil, fmt.Errorf("NewFileStore: %s:%d: %w", path, line, err)
+			}
+			fs.addMatch(m)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("NewFileStore: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("NewFileStore: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("NewFileStore: %w", err)
+	}
+	fs.file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("NewFileStore: %w", err)
+	}
+	return fs, nil
+}
+
+func (fs *FileStore) AddMatch(m *MatchRecord) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	// 레이팅 변동이 기록된 매치 결과를 파일에 추가
+	fs.addMatch(m)
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("FileStore.AddMatch: %w", err)
+	}
+	if _, err := fs.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("FileStore.AddMatch: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// 게스트는 매치 기록에만 남고 플레이어별 기록, 통계, 리더보드에 쌓이지 않음
+func TestMemoryStoreSkipsGuests(t *testing.T) {
+	ms := NewMemoryStore()
+	for i := 0; i < 3; i++ {
+		ms.AddMatch(&MatchRecord{
+			Id:        fmt.Sprint("GAME", i),
+			StartedAt: time.Now(),
+			Participants: []Participant{
+				{PlayerId: "ACCOUNT01", Placement: 1},
+				{PlayerId: fmt.Sprint("GUEST0", i), Guest: true, Placement: 2},
+			},
+		})
+	}
+
+	if len(ms.stats) != 1 || len(ms.byPlayer) != 1 {
+		t.Fatalf("players indexed = %d stats, %d match lists, want 1", len(ms.stats), len(ms.byPlayer))
+	}
+	if _, ok := ms.PlayerStats("GUEST00"); ok {
+		t.Error("guest has player stats")
+	}
+	matches, total := ms.PlayerMatches("ACCOUNT01", 0, 10)
+	if total != 3 || len(matches[0].Participants) != 2 {
+		t.Fatalf("PlayerMatches = %d matches, want 3 with guest participant", total)
+	}
+	guest := matches[0].Participants[1]
+	if guest.RatingBefore != RATING_DEFAULT {
+		t.Errorf("guest RatingBefore = %v, want %v", guest.RatingBefore, RATING_DEFAULT)
+	}
+	if entries, total := ms.Leaderboard(SEASON_GLOBAL, LEADERBOARD_SORT_RATING, 0, 10); total != 1 || entries[0].PlayerId != "ACCOUNT01" {
+		t.Errorf("Leaderboard = %v, want only ACCOUNT01", entries)
+	}
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// LOG_LEVEL(debug, info, warn, error)과 LOG_FORMAT(text, json) 환경 변수로 기본 로거 설정
+func SetupLogger() {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(Getevn("LOG_LEVEL", "info"))); err != nil {
+		Fatal("invalid LOG_LEVEL", "err", err)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+	switch strings.ToLower(Getevn("LOG_FORMAT", "text")) {
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	default:
+		Fatal("invalid LOG_FORMAT", "format", Getevn("LOG_FORMAT", ""))
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+// 에러 로그를 남기고 프로그램 종료
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
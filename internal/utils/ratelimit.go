@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+// 토큰 버킷 방식의 요청 빈도 제한: 초당 rate개의 토큰이 최대 burst개까지 채워지며, 요청마다 토큰 하나를 소모
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// 토큰이 남아 있으면 하나를 소모하고 true 반환
+func (tb *TokenBucket) Allow() bool {
+	return tb.AllowAt(time.Now())
+}
+
+// now 시각 기준으로 토큰을 채운 후 Allow와 같이 처리
+func (tb *TokenBucket) AllowAt(now time.Time) bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.tokens = min(tb.tokens+max(now.Sub(tb.last), 0).Seconds()*tb.rate, tb.burst)
+	tb.last = now
+	if tb.tokens < 1 {
+		return false
+	}
+	tb.tokens--
+	return true
+}
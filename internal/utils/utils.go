@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"math"
 	"math/rand"
 	"os"
 )
@@ -25,6 +26,31 @@ func CircleCollision(x1, y1, r1, x2, y2, r2 float64) bool {
 	return dx*dx+dy*dy <= sumR*sumR
 }
 
+// 원의 중심이 (x0, y0)에서 (x1, y1)로 이동하는 동안 (cx, cy)와의 거리가 처음으로 r(두 원의 반지름 합) 이하가 되는 시점
+// 시점은 이동 구간의 비율(0~1)이며, 이동 전부터 겹쳐 있으면 0, 닿지 않으면 false 반환
+func SweptCircleCollision(x0, y0, x1, y1, cx, cy, r float64) (float64, bool) {
+	dx, dy := x1-x0, y1-y0
+	fx, fy := x0-cx, y0-cy
+	c := fx*fx + fy*fy - r*r
+	if c <= 0 {
+		return 0, true
+	}
+	a := dx*dx + dy*dy
+	if a == 0 {
+		return 0, false
+	}
+	b := 2 * (fx*dx + fy*dy)
+	disc := b*b - 4*a*c
+	if disc < 0 {
+		return 0, false
+	}
+	t := (-b - math.Sqrt(disc)) / (2 * a)
+	if t < 0 || t > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
 func Getevn(key string, defaultValue string) string {
 	v := os.Getenv(key)
 	if v == "" {
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSweptCircleCollision(t *testing.T) {
+	tests := []struct {
+		name           string
+		x0, y0, x1, y1 float64
+		cx, cy, r      float64
+		wantT          float64
+		wantOk         bool
+	}{
+		{name: "hit in the middle of the path", x0: 0, y0: 0, x1: 20, y1: 0, cx: 10, cy: 0, r: 5, wantT: 0.25, wantOk: true},
+		{name: "overlapping at start", x0: 0, y0: 0, x1: 20, y1: 0, cx: 2, cy: 1, r: 5, wantT: 0, wantOk: true},
+		{name: "overlapping at start moving away", x0: 0, y0: 0, x1: -20, y1: 0, cx: 2, cy: 0, r: 5, wantT: 0, wantOk: true},
+		{name: "zero-length path outside", x0: 0, y0: 0, x1: 0, y1: 0, cx: 10, cy: 0, r: 5, wantOk: false},
+		{name: "zero-length path inside", x0: 9, y0: 0, x1: 9, y1: 0, cx: 10, cy: 0, r: 5, wantT: 0, wantOk: true},
+		{name: "tangent graze", x0: -10, y0: 5, x1: 10, y1: 5, cx: 0, cy: 0, r: 5, wantT: 0.5, wantOk: true},
+		{name: "just outside tangent", x0: -10, y0: 5.01, x1: 10, y1: 5.01, cx: 0, cy: 0, r: 5, wantOk: false},
+		{name: "hit only past end of path", x0: 0, y0: 0, x1: 10, y1: 0, cx: 20, cy: 0, r: 5, wantOk: false},
+		{name: "reaches target exactly at end", x0: 0, y0: 0, x1: 15, y1: 0, cx: 20, cy: 0, r: 5, wantT: 1, wantOk: true},
+		{name: "target behind start", x0: 0, y0: 0, x1: 20, y1: 0, cx: -10, cy: 0, r: 5, wantOk: false},
+		{name: "passes through small target", x0: 0, y0: 0, x1: 16, y1: 0, cx: 8, cy: 0, r: 1, wantT: 7.0 / 16, wantOk: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotT, gotOk := SweptCircleCollision(tt.x0, tt.y0, tt.x1, tt.y1, tt.cx, tt.cy, tt.r)
+			if gotOk != tt.wantOk {
+				t.Fatalf("ok = %v, want %v (t = %v)", gotOk, tt.wantOk, gotT)
+			}
+			if gotOk && math.Abs(gotT-tt.wantT) > 1e-9 {
+				t.Errorf("t = %v, want %v", gotT, tt.wantT)
+			}
+		})
+	}
+}
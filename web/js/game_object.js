@@ -31,11 +31,35 @@ const PLAYER_ROTATE_NONE = 0;
 const PLAYER_ROTATE_LEFT = 1;
 const PLAYER_ROTATE_RIGTH = 2;
 
+// 비행 모델: 서버와 동일한 값이어야 함
+const FLIGHT_MODEL_ARCADE = "arcade";
+const FLIGHT_MODEL_NEWTONIAN = "newtonian";
+
+// 관성 및 newtonian 비행 모델 상수: 서버와 동일한 값이어야 함
+const PLAYER_INERTIA_DECAY = 3;
+const PLAYER_INERTIA_MIN = 1;
+const PLAYER_THRUST = GAME_OBJECT_WIDTH * 5;
+const PLAYER_DRAG = 1.5;
+const PLAYER_MAX_VELOCITY = GAME_OBJECT_WIDTH * 4;
+const PLAYER_ANGULAR_THRUST = 4;
+const PLAYER_ANGULAR_DRAG = 3;
+const PLAYER_ANGULAR_MIN = 0.01;
+
+// 레이저 발사 상수: 쿨다운은 서버와 동일한 값이어야 함
+const PLAYER_FIRE_COOLDOWN = 1.5;
+const PLAYER_FIRE_RETRY = 0.1; // 발사 키를 누르고 있는 동안 쿨다운이 끝난 후 발사 요청을 다시 보내는 간격(sec)
+
+// 대시 상수: 서버와 동일한 값이어야 함
+const PLAYER_BOOST_COOLDOWN = 5;
+const PLAYER_BOOST_DURATION = 0.25;
+const PLAYER_BOOST_SPEED = GAME_OBJECT_WIDTH * 8;
+
 // 게임 플레이어
 class Player {
-    constructor(id, idx, x, y, angle, moveSpeed, rotateSpeed) {
+    constructor(id, idx, x, y, angle, moveSpeed, rotateSpeed, name = "", skin = idx, flightModel = FLIGHT_MODEL_ARCADE) {
         this.id = id;
         this.idx = idx;
+        this.name = name;
         this.w = GAME_OBJECT_WIDTH;
         this.h = GAME_OBJECT_HEIGHT;
         this.x = x;
@@ -44,31 +68,117 @@ class Player {
         this.dirX = 0;
         this.dirY = 0;
         this.dirR = 0;
+        this.vx = 0; // 관성 속도
+        this.vy = 0;
+        this.vr = 0; // 각속도(newtonian 비행 모델)
+        this.flightModel = flightModel || FLIGHT_MODEL_ARCADE;
+        this.boostTime = 0;     // 남은 대시 시간(sec)
+        this.boostCooldown = 0; // 남은 대시 쿨다운 시간(sec)
+        this.fireCooldown = 0; // 남은 레이저 발사 쿨다운 시간(sec), 내 플레이어만 사용
         this.alpha = 1;
         this.isDead = false;
         this.moveSpeed = moveSpeed;
         this.rotateSpeed = rotateSpeed;
-        this.shipBodyFrame = playerShipBodyFrame[idx];
+        this.shipBodyFrame = playerShipBodyFrame[skin];
+    }
+
+    // 서버에서 받은 속도 적용: 다음 동기화까지 속도로 위치 예측
+    setVelocity(data) {
+        this.vx = data.vx || 0;
+        this.vy = data.vy || 0;
+        this.vr = data.vr || 0;
+    }
+
+    // 서버에서 받은 대시 적용: 대시 속도가 포함된 위치와 속도로 갱신
+    boost(data) {
+        this.x = data.x;
+        this.y = data.y;
+        this.angle = data.angle;
+        this.vx = data.vx || 0;
+        this.vy = data.vy || 0;
+        this.boostTime = PLAYER_BOOST_DURATION;
+        this.boostCooldown = data.cooldown;
+    }
+
+    // 입력 방향의 단위 벡터
+    inputDirection() {
+        let vx = Math.cos(this.angle) * this.dirX + Math.cos(this.angle + Math.PI / 2) * this.dirY;
+        let vy = Math.sin(this.angle) * this.dirX + Math.sin(this.angle + Math.PI / 2) * this.dirY;
+        const len = Math.hypot(vx, vy);
+        if (len === 0) {
+            return [0, 0];
+        }
+        return [vx / len, vy / len];
     }
 
     update(dt) {
         if (this.isDead) {
             return;
         }
+        this.boostTime = Math.max(this.boostTime - dt, 0);
+        this.boostCooldown = Math.max(this.boostCooldown - dt, 0);
+        this.fireCooldown = Math.max(this.fireCooldown - dt, 0);
+        if (this.flightModel === FLIGHT_MODEL_NEWTONIAN) {
+            this.updateNewtonian(dt);
+        } else {
+            this.updateArcade(dt);
+        }
+    }
 
+    updateArcade(dt) {
         // 회전 업데이트
         this.angle += this.rotateSpeed * dt * this.dirR;
 
-        // 이동 업데이트
-        let vx = Math.cos(this.angle) * this.dirX + Math.cos(this.angle + Math.PI / 2) * this.dirY;
-        let vy = Math.sin(this.angle) * this.dirX + Math.sin(this.angle + Math.PI / 2) * this.dirY;
-        let len = Math.hypot(vx, vy);
-        if (len > 0) {
-            vx /= len;
-            vy /= len;
-            this.x += vx * this.moveSpeed * dt;
-            this.y += vy * this.moveSpeed * dt;
+        // 입력 방향 이동과 관성 속도 적용
+        const [dx, dy] = this.inputDirection();
+        this.x += (dx * this.moveSpeed + this.vx) * dt;
+        this.y += (dy * this.moveSpeed + this.vy) * dt;
+
+        // 관성 속도 감쇠
+        const decay = Math.exp(-PLAYER_INERTIA_DECAY * dt);
+        this.vx *= decay;
+        this.vy *= decay;
+        if (Math.hypot(this.vx, this.vy) < PLAYER_INERTIA_MIN) {
+            this.vx = 0;
+            this.vy = 0;
+        }
+    }
+
+    updateNewtonian(dt) {
+        // 회전: 회전 입력으로 각속도 가속, 최대 각속도는 rotateSpeed
+        this.vr += PLAYER_ANGULAR_THRUST * dt * this.dirR;
+        this.vr *= Math.exp(-PLAYER_ANGULAR_DRAG * dt);
+        this.vr = Math.max(-this.rotateSpeed, Math.min(this.vr, this.rotateSpeed));
+        if (this.dirR === 0 && Math.abs(this.vr) < PLAYER_ANGULAR_MIN) {
+            this.vr = 0;
+        }
+        this.angle += this.vr * dt;
+
+        // 이동: 입력 방향으로 추력을 가하고 항력으로 감속
+        const [ax, ay] = this.inputDirection();
+        this.vx += ax * PLAYER_THRUST * dt;
+        this.vy += ay * PLAYER_THRUST * dt;
+        const drag = Math.exp(-PLAYER_DRAG * dt);
+        this.vx *= drag;
+        this.vy *= drag;
+        const maxVelocity = this.maxVelocity();
+        const speed = Math.hypot(this.vx, this.vy);
+        if (speed > maxVelocity) {
+            this.vx *= maxVelocity / speed;
+            this.vy *= maxVelocity / speed;
+        } else if (ax === 0 && ay === 0 && speed < PLAYER_INERTIA_MIN) {
+            this.vx = 0;
+            this.vy = 0;
+        }
+        this.x += this.vx * dt;
+        this.y += this.vy * dt;
+    }
+
+    maxVelocity() {
+        if (this.boostTime > 0) {
+            return PLAYER_MAX_VELOCITY + PLAYER_BOOST_SPEED;
         }
+        return PLAYER_MAX_VELOCITY;
     }
 
     draw(ctx) {
@@ -86,7 +196,7 @@ class Player {
             shipBodyFrame.x, shipBodyFrame.y, shipBodyFrame.w, shipBodyFrame.h,
             -(this.w / 2), -(this.h / 2), this.w, this.h
         );
-        if (this.dirX !== 0 || this.dirY !== 0 || this.dirR !== 0) {
+        if (this.dirX !== 0 || this.dirY !== 0 || this.dirR !== 0 || this.boostTime > 0) {
             const shipBoostFrame = playerShipBoostFrame[rotateStatus];
             ctx.drawImage(spriteSheetImg,
                 shipBoostFrame.x, shipBoostFrame.y, shipBoostFrame.w, shipBoostFrame.h,
@@ -132,6 +242,57 @@ class Projectile {
     }
 };
 
+// 장애물 타입: 서버와 동일한 값이어야 함
+const OBSTACLE_TYPE_ROCK = 0;
+const OBSTACLE_TYPE_CRYSTAL = 1;
+const OBSTACLE_TYPE_ASTEROID = 2;
+
+// 장애물 타입별 색상
+const obstacleColor = [
+    {fill: "rgba(110, 100, 95, 0.9)", stroke: "rgba(160, 150, 140, 1)"},  // 암석
+    {fill: "rgba(80, 200, 230, 0.35)", stroke: "rgba(150, 240, 255, 1)"}, // 크리스탈
+    {fill: "rgba(140, 95, 60, 0.9)", stroke: "rgba(200, 150, 100, 1)"},   // 소행성
+];
+
+// 장애물 오브젝트
+class Obstacle {
+    constructor(id, type, x, y, r, angle, moveSpeed, hp) {
+        this.id = id;
+        this.type = type;
+        this.x = x;
+        this.y = y;
+        this.r = r;
+        this.angle = angle;
+        this.moveSpeed = moveSpeed;
+        this.hp = hp;
+        this.hitTime = 0;
+    }
+
+    update(dt) {
+        this.x += Math.cos(this.angle) * this.moveSpeed * dt;
+        this.y += Math.sin(this.angle) * this.moveSpeed * dt;
+        this.hitTime = Math.max(this.hitTime - dt, 0);
+    }
+
+    hit(hp) {
+        this.hp = hp;
+        this.hitTime = 0.15;
+    }
+
+    draw(ctx) {
+        const color = obstacleColor[this.type] || obstacleColor[OBSTACLE_TYPE_ROCK];
+        ctx.save();
+        ctx.beginPath();
+        ctx.arc(0, 0, this.r, 0, Math.PI * 2);
+        ctx.fillStyle = this.hitTime > 0 ? "rgba(255, 255, 255, 0.9)" : color.fill;
+        ctx.fill();
+        ctx.strokeStyle = color.stroke;
+        ctx.lineWidth = 2;
+        ctx.stroke();
+        ctx.restore();
+    }
+};
+
 // 이펙트 오브젝트 타입
 const EFFECT_TYPE_EXPLOSION = 0;
 
@@ -255,9 +416,19 @@ class GameWorld {
         this.area = 0;
         this.min_area = 0;
         this.speed = 0;
+        this.wait = 0;
+    }
+
+    // 서버에서 받은 월드 범위와 축소 일정 적용
+    setZone(data) {
+        this.area = data.x;
+        this.min_area = data.y;
+        this.speed = data.move_speed;
+        this.wait = data.wait || 0;
     }
 
     update(dt) {
+        this.wait = Math.max(this.wait - dt, 0);
         this.area -= this.speed * dt;
         if (this.area < this.min_area) {
             this.area = this.min_area;
@@ -20,7 +20,9 @@ class SceneGame {
         this.myPlayer = new Player(id, 0, 0, 0, 0, 0, 0);
         this.players = new Map();
         this.projectiles = new Map();
+        this.obstacles = new Map();
         this.effects = [];
+        this.latency = 0;
         this.centerX = this.canvas.width / 2;
         this.centerY = this.canvas.height / 2 + 100;
 
@@ -28,6 +30,8 @@ class SceneGame {
         this.inputDirY = 0;
         this.inputDirR = 0;
         this.inputFire = false;
+        this.fireRetry = 0; // 다음 발사 요청까지 남은 시간(sec)
+        this.inputBoost = false;
         this.input_keys = {};
         addEventListener('keydown', e => {
             this.input_keys[e.key.toLowerCase()] = true;
@@ -43,7 +47,7 @@ class SceneGame {
         }
     }
 
-    updateInput() {
+    updateInput(dt) {
         if (this.myPlayer.isDead) {
             return;
         }
@@ -64,20 +68,32 @@ class SceneGame {
         this.inputDirY = dirY;
         this.inputDirR = dirR;
 
-        // 레이저 발사 입력 체크
+        // 레이저 발사 입력 체크: 키를 누르면 바로 전송하고, 누르고 있는 동안은 쿨다운이 끝난 후 일정 간격으로 전송
         let inputFire = false;
         if (this.input_keys['l']) inputFire = true;
-        if (this.inputFire === true && inputFire !== false) {
+        this.fireRetry = Math.max(this.fireRetry - dt, 0);
+        if (inputFire === true && (this.inputFire === false ||
+            (this.myPlayer.fireCooldown <= 0 && this.fireRetry <= 0))) {
             const ev = {type: 'player_fire', owner_id: this.id};
             ws.send(JSON.stringify({type: 'ingame', client_id: this.id, event: ev}));
+            this.fireRetry = PLAYER_FIRE_RETRY;
         }
         this.inputFire = inputFire;
+
+        // 대시 입력 체크
+        let inputBoost = false;
+        if (this.input_keys['shift']) inputBoost = true;
+        if (this.inputBoost === false && inputBoost === true) {
+            const ev = {type: 'player_boost', owner_id: this.id};
+            ws.send(JSON.stringify({type: 'ingame', client_id: this.id, event: ev}));
+        }
+        this.inputBoost = inputBoost;
     }
 
     updateAndDraw(dt) {
         // 입력 업데이트
         if (this.status !== GAME_SCENE_STATUS_END) {
-            this.updateInput();
+            this.updateInput(dt);
         }
 
         // 게임 월드 업데이트 및 그리기
@@ -110,6 +126,15 @@ class SceneGame {
             }
         }
 
+        // 장애물 업데이트 및 그리기
+        for (const [id, obstacle] of this.obstacles) {
+            obstacle.update(dt);
+            drawGameObj(
+                this.ctx, obstacle, this.centerX, this.centerY,
+                this.myPlayer.x, this.myPlayer.y, this.myPlayer.angle
+            );
+        }
+
         // 플레이어 업데이트 및 그리기
         for (const [id, player] of this.players) {
             player.update(dt);
@@ -157,6 +182,31 @@ class SceneGame {
         }
         this.effects.filter(effect => effect.isDead);
 
+        // 대시 쿨다운 표시
+        if (!this.myPlayer.isDead && this.status !== GAME_SCENE_STATUS_END) {
+            this.drawBoostIndicator();
+        }
+
+        // 월드 범위 축소 대기 시간 표시
+        if (this.gameWorld.wait > 0 && this.status !== GAME_SCENE_STATUS_END) {
+            this.ctx.save();
+            this.ctx.fillStyle = "#c0b0ff";
+            this.ctx.font = "14px monospace";
+            this.ctx.textAlign = "center";
+            this.ctx.fillText("zone shrinks in " + Math.ceil(this.gameWorld.wait) + "s", this.canvas.width / 2, 24);
+            this.ctx.restore();
+        }
+
+        // 네트워크 지연 시간 표시
+        if (this.latency > 0) {
+            this.ctx.save();
+            this.ctx.fillStyle = this.latency > 150 ? "#ff6060" : "#a0a0a0";
+            this.ctx.font = "12px monospace";
+            this.ctx.textAlign = "right";
+            this.ctx.fillText(Math.round(this.latency) + " ms", this.canvas.width - 10, 20);
+            this.ctx.restore();
+        }
+
         // 게임이 종료된 경우
         if (this.status === GAME_SCENE_STATUS_END){
             this.endGameImage.alpha += 1 * dt;
@@ -173,16 +223,16 @@ class SceneGame {
         if (msg.type === 'ingame') {
             const ev = msg.event;
             const data = ev.data;
-            if (ev.type === 'game_init') {
-                this.gameWorld.area = data.x;
-                this.gameWorld.min_area = data.y;
-                this.gameWorld.speed = data.move_speed;
+            if (ev.type === 'game_init' || ev.type === 'game_zone') {
+                // 월드 범위 및 축소 일정 갱신
+                this.gameWorld.setZone(data);
             } else if (ev.type === 'game_victory') {
                 // 게임 승리
                 this.endGame(true);
             } else if (ev.type === 'player_create') {
                 const player = new Player(ev.owner_id, data.idx,
-                    data.x, data.y, data.angle, data.move_speed, data.rotate_speed);
+                    data.x, data.y, data.angle, data.move_speed, data.rotate_speed, data.name, data.skin, data.flight_model);
+                player.setVelocity(data);
                 this.players.set(ev.owner_id, player);
                 if (this.id === ev.owner_id) {
                     this.myPlayer = player;
@@ -203,15 +253,79 @@ class SceneGame {
                 player.dirX = data.dir_x;
                 player.dirY = data.dir_y;
                 player.dirR = data.dir_r;
+                player.setVelocity(data);
+            } else if (ev.type === 'player_boost') {
+                const player = this.players.get(ev.owner_id);
+                if (player) {
+                    player.boost(data);
+                }
+            } else if (ev.type === 'player_boost_ready') {
+                this.myPlayer.boostCooldown = 0;
+            } else if (ev.type === 'player_boost_cooldown') {
+                this.myPlayer.boostCooldown = data.cooldown;
             } else if (ev.type === 'projectile_create') {
                 const projectile = new Projectile(ev.owner_id, data.idx, data.x, data.y, data.angle, data.move_speed);
                 this.projectiles.set(data.id, projectile);
+                if (ev.owner_id === this.id) {
+                    // 서버에서 발사된 시점부터 쿨다운 시작: 전달 지연(왕복 시간의 절반)만큼 먼저 시작됨
+                    this.myPlayer.fireCooldown = Math.max(PLAYER_FIRE_COOLDOWN - this.latency / 2000, 0);
+                }
             } else if (ev.type === 'projectile_extinction') {
                 this.projectiles.delete(data.id);
+            } else if (ev.type === 'projectile_deflect') {
+                // 반사된 발사체의 위치와 진행 방향 갱신
+                const projectile = this.projectiles.get(data.id);
+                if (projectile) {
+                    projectile.x = data.x;
+                    projectile.y = data.y;
+                    projectile.angle = data.angle;
+                    projectile.moveSpeed = data.move_speed;
+                }
+            } else if (ev.type === 'obstacle_create') {
+                const obstacle = new Obstacle(data.id, data.idx, data.x, data.y, data.r, data.angle, data.move_speed, data.hp);
+                this.obstacles.set(data.id, obstacle);
+            } else if (ev.type === 'obstacle_move') {
+                const obstacle = this.obstacles.get(data.id);
+                if (obstacle) {
+                    obstacle.x = data.x;
+                    obstacle.y = data.y;
+                    obstacle.angle = data.angle;
+                    obstacle.moveSpeed = data.move_speed;
+                }
+            } else if (ev.type === 'obstacle_hit') {
+                const obstacle = this.obstacles.get(data.id);
+                if (obstacle) {
+                    obstacle.hit(data.hp);
+                }
+            } else if (ev.type === 'obstacle_destroy') {
+                const obstacle = this.obstacles.get(data.id);
+                if (obstacle) {
+                    this.obstacles.delete(data.id);
+                    this.effects.push(new Effect(data.id, EFFECT_TYPE_EXPLOSION, obstacle.x, obstacle.y, obstacle.angle));
+                }
+            } else if (ev.type === 'player_latency') {
+                this.latency = data.latency;
             }
         }
     }
 
+    // 화면 좌측 하단에 대시 사용 가능 여부와 남은 쿨다운 표시
+    drawBoostIndicator() {
+        const x = 10, y = this.canvas.height - 24, w = 80, h = 6;
+        const cooldown = this.myPlayer.boostCooldown;
+        const ready = cooldown <= 0;
+        this.ctx.save();
+        this.ctx.font = "12px monospace";
+        this.ctx.textAlign = "left";
+        this.ctx.fillStyle = ready ? "#80ff80" : "#a0a0a0";
+        this.ctx.fillText(ready ? "DASH READY" : "DASH " + cooldown.toFixed(1) + "s", x, y - 6);
+        this.ctx.fillStyle = "rgba(255, 255, 255, 0.2)";
+        this.ctx.fillRect(x, y, w, h);
+        this.ctx.fillStyle = ready ? "#80ff80" : "#6080ff";
+        this.ctx.fillRect(x, y, w * (1 - cooldown / PLAYER_BOOST_COOLDOWN), h);
+        this.ctx.restore();
+    }
+
     endGame(win = false) {
         this.status = GAME_SCENE_STATUS_END;
         if (win) {